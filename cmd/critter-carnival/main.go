@@ -1,14 +1,18 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/stylecache"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -20,16 +24,6 @@ const (
 	minStageHeight = 16
 )
 
-type spriteFrame []string
-
-type pixelPalette map[rune]string
-
-type styleKey struct {
-	fg   string
-	bg   string
-	bold bool
-}
-
 type cell struct {
 	ch   rune
 	fg   string
@@ -43,16 +37,13 @@ type model struct {
 	ready       bool
 	rng         *rand.Rand
 	t           float64
-	sprite      []spriteFrame
-	palette     pixelPalette
-	frame       int
-	frameTimer  float64
-	frameSpeed  float64
+	sprite      *sprite.Sprite
 	hoverRadius float64
 	hoverSpeed  float64
 	colorPulse  float64
 
-	styleCache map[styleKey]lipgloss.Style
+	styleCache *stylecache.Cache
+	cacheStats bool
 	backdrop   background
 }
 
@@ -66,8 +57,25 @@ type background struct {
 type frameMsg time.Time
 
 func main() {
+	cacheStats := flag.Bool("cache-stats", false, "show style cache size and hit rate under the stage")
+	spriteDir := flag.String("sprites", sprite.DefaultDir(), "directory of .sprite files that add to or replace the built-in ones")
+	spriteName := flag.String("sprite", "celestial-fox", "sprite to fly across the stage")
+	flag.Parse()
+
+	lib, err := sprite.Load(*spriteDir)
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	familiar, err := lib.Get(*spriteName)
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+
 	rand.Seed(time.Now().UnixNano())
-	m := newModel()
+	m := newModel(familiar)
+	m.cacheStats = *cacheStats
 	p := tea.NewProgram(m, tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
 		fmt.Println("error:", err)
@@ -75,16 +83,14 @@ func main() {
 	}
 }
 
-func newModel() model {
+func newModel(familiar *sprite.Sprite) model {
 	return model{
 		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
-		sprite:      foxSpriteFrames,
-		palette:     foxPalette,
-		frameSpeed:  0.15,
+		sprite:      familiar,
 		hoverRadius: 6,
 		hoverSpeed:  0.45,
 		colorPulse:  0.35,
-		styleCache:  make(map[styleKey]lipgloss.Style),
+		styleCache:  stylecache.New(stylecache.DefaultCapacity),
 		backdrop: background{
 			skyPalette:    []string{"#040726", "#101d46", "#283a7a", "#4c5bbb"},
 			groundPalette: []string{"#0c1f1d", "#123530", "#1c4f46", "#2a6f62"},
@@ -118,11 +124,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tick()
 		}
 		m.t += deltaTime
-		m.frameTimer += deltaTime
-		if len(m.sprite) > 0 && m.frameSpeed > 0 && m.frameTimer >= m.frameSpeed {
-			m.frameTimer = math.Mod(m.frameTimer, m.frameSpeed)
-			m.frame = (m.frame + 1) % len(m.sprite)
-		}
 		return m, tick()
 	default:
 		return m, nil
@@ -144,12 +145,16 @@ func (m model) View() string {
 
 	stage := renderCanvas(canvas, m.styleCache)
 
-	info := renderStatus(m.width, foxPalette, m.t)
+	info := renderStatus(m.width, m.sprite, m.t)
 
 	var b strings.Builder
 	b.WriteString(stage)
 	b.WriteByte('\n')
 	b.WriteString(info)
+	if m.cacheStats {
+		b.WriteByte('\n')
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("109")).Render(m.styleCache.Stats().String()))
+	}
 	return b.String()
 }
 
@@ -162,10 +167,10 @@ func (m model) stageSize() (int, int) {
 }
 
 func (m model) drawSprite(canvas [][]cell) {
-	if len(m.sprite) == 0 {
+	if m.sprite == nil {
 		return
 	}
-	frame := m.sprite[m.frame%len(m.sprite)]
+	frame := m.sprite.FrameAt(time.Duration(m.t * float64(time.Second)))
 	stageHeight := len(canvas)
 	if stageHeight == 0 {
 		return
@@ -186,7 +191,7 @@ func (m model) drawSprite(canvas [][]cell) {
 	brightness := 0.5 + 0.5*math.Sin(m.t*m.colorPulse)
 	tint := blendHex("#f472b6", "#94f7d1", brightness)
 
-	paintFrame(canvas, int(math.Round(x))-len(frame[0])/2, int(math.Round(y))-len(frame)/2, frame, tint, m.palette)
+	paintFrame(canvas, int(math.Round(x))-m.sprite.AnchorX, int(math.Round(y))-m.sprite.AnchorY, frame.Rows, tint, m.sprite.Palette)
 }
 
 func (m model) drawBackdrop(canvas [][]cell) {
@@ -291,20 +296,41 @@ func (m model) drawFireflies(canvas [][]cell) {
 	}
 }
 
-func renderStatus(width int, palette pixelPalette, t float64) string {
+func renderStatus(width int, familiar *sprite.Sprite, t float64) string {
 	titleStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("213"))
 	glow := 0.5 + 0.5*math.Sin(t*0.9)
-	accent := blendHex(palette['5'], palette['2'], glow)
+	from, to := accentColors(familiar)
+	accent := blendHex(from, to, glow)
 	accentStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(accent))
 
 	lines := []string{
 		titleStyle.Render("Celestial Familiar"),
-		accentStyle.Render("A single fox spirits through aurora lullabies"),
+		accentStyle.Render(fmt.Sprintf("A single %s spirits through aurora lullabies", strings.ToLower(familiar.Name))),
 		lipgloss.NewStyle().Foreground(lipgloss.Color("109")).Render("Use Ctrl+C or q to leave the dream"),
 	}
 	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
 }
 
+// accentColors picks the status line's two glow colours from the first hex
+// entries of the sprite's palette in glyph order, falling back to the aurora's
+// own colours for sprites with fewer than two.
+func accentColors(familiar *sprite.Sprite) (string, string) {
+	glyphs := make([]rune, 0, len(familiar.Palette))
+	for g := range familiar.Palette {
+		glyphs = append(glyphs, g)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+
+	colors := []string{}
+	for _, g := range glyphs {
+		if c := familiar.Palette[g]; strings.HasPrefix(c, "#") && len(c) == 7 {
+			colors = append(colors, c)
+		}
+	}
+	colors = append(colors, "#fef3c7", "#a855f7")
+	return colors[0], colors[1]
+}
+
 func newCanvas(width, height int) [][]cell {
 	if width <= 0 || height <= 0 {
 		return [][]cell{}
@@ -320,7 +346,7 @@ func newCanvas(width, height int) [][]cell {
 	return canvas
 }
 
-func paintFrame(canvas [][]cell, startX, startY int, frame spriteFrame, fallback string, palette pixelPalette) {
+func paintFrame(canvas [][]cell, startX, startY int, frame []string, fallback string, palette map[rune]string) {
 	height := len(frame)
 	if height == 0 {
 		return
@@ -335,8 +361,8 @@ func paintFrame(canvas [][]cell, startX, startY int, frame spriteFrame, fallback
 		if y < 0 || y >= stageHeight {
 			continue
 		}
-		for dx, r := range line {
-			if r == ' ' {
+		for dx, r := range []rune(line) {
+			if sprite.IsTransparent(r) {
 				continue
 			}
 			x := startX + dx
@@ -362,7 +388,7 @@ func paintFrame(canvas [][]cell, startX, startY int, frame spriteFrame, fallback
 	}
 }
 
-func renderCanvas(canvas [][]cell, cache map[styleKey]lipgloss.Style) string {
+func renderCanvas(canvas [][]cell, cache *stylecache.Cache) string {
 	if len(canvas) == 0 {
 		return ""
 	}
@@ -380,7 +406,7 @@ func renderCanvas(canvas [][]cell, cache map[styleKey]lipgloss.Style) string {
 		var segment strings.Builder
 		for _, c := range row {
 			if c.fg != currentFg || c.bg != currentBg || c.bold != currentBold {
-				b.WriteString(applyStyle(segment.String(), currentFg, currentBg, currentBold, cache))
+				b.WriteString(cache.Render(segment.String(), currentFg, currentBg, currentBold))
 				segment.Reset()
 				currentFg = c.fg
 				currentBg = c.bg
@@ -392,7 +418,7 @@ func renderCanvas(canvas [][]cell, cache map[styleKey]lipgloss.Style) string {
 				segment.WriteRune(c.ch)
 			}
 		}
-		b.WriteString(applyStyle(segment.String(), currentFg, currentBg, currentBold, cache))
+		b.WriteString(cache.Render(segment.String(), currentFg, currentBg, currentBold))
 		if y < len(canvas)-1 {
 			b.WriteByte('\n')
 		}
@@ -400,28 +426,6 @@ func renderCanvas(canvas [][]cell, cache map[styleKey]lipgloss.Style) string {
 	return b.String()
 }
 
-func applyStyle(text string, fg string, bg string, bold bool, cache map[styleKey]lipgloss.Style) string {
-	if text == "" {
-		return ""
-	}
-	key := styleKey{fg: fg, bg: bg, bold: bold}
-	style, ok := cache[key]
-	if !ok {
-		style = lipgloss.NewStyle()
-		if fg != "" {
-			style = style.Foreground(lipgloss.Color(fg))
-		}
-		if bg != "" {
-			style = style.Background(lipgloss.Color(bg))
-		}
-		if bold {
-			style = style.Bold(true)
-		}
-		cache[key] = style
-	}
-	return style.Render(text)
-}
-
 func gradientColor(palette []string, t float64) string {
 	if len(palette) == 0 {
 		return ""
@@ -504,34 +508,3 @@ func max(a, b int) int {
 	}
 	return b
 }
-
-var (
-	foxPalette = pixelPalette{
-		'1': "#f97316",
-		'2': "#fb923c",
-		'3': "#1f2937",
-		'4': "#fef3c7",
-		'5': "#f472b6",
-	}
-
-	foxSpriteFrames = []spriteFrame{
-		{
-			"    222222    ",
-			"  222222222   ",
-			" 22221111222  ",
-			"2221333313222 ",
-			" 22134444122  ",
-			"  22111152    ",
-			"    21152     ",
-		},
-		{
-			"    222222    ",
-			"  222222222   ",
-			" 22221111222  ",
-			"2221333313222 ",
-			" 22134444122  ",
-			"   2211152    ",
-			"    211552    ",
-		},
-	}
-)
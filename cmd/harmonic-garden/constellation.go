@@ -0,0 +1,196 @@
+package main
+
+import (
+	"math"
+	"sort"
+)
+
+type linkMode int
+
+const (
+	linkOff linkMode = iota
+	linkNear
+	linkChain
+	linkTree
+	linkNeighbours
+	linkModeCount
+)
+
+var linkModeNames = []string{"off", "near", "chain", "tree", "neighbours"}
+
+const (
+	// linkReach is the "near" threshold as a fraction of the stage width.
+	linkReach      = 0.22
+	linkNeighbourK = 2
+)
+
+type link struct {
+	a, b int
+	dist float64
+}
+
+// linkDistance measures in square-ish units: terminal cells are roughly twice
+// as tall as they are wide.
+func linkDistance(a, b vector) float64 {
+	return math.Hypot(a.x-b.x, (a.y-b.y)*2)
+}
+
+func (m *model) cycleConstellation() {
+	m.constellation = linkMode((int(m.constellation) + 1) % int(linkModeCount))
+}
+
+// constellationLinks returns the edges for the active mode along with the
+// distance used to normalise their colour.
+func (m *model) constellationLinks() ([]link, float64) {
+	n := len(m.followers)
+	if n < 2 {
+		return nil, 1
+	}
+	reach := float64(m.canvasWidth) * linkReach
+	pos := make([]vector, n)
+	for i, f := range m.followers {
+		pos[i] = f.pos
+	}
+	var links []link
+	switch m.constellation {
+	case linkNear:
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if d := linkDistance(pos[i], pos[j]); d <= reach {
+					links = append(links, link{i, j, d})
+				}
+			}
+		}
+		return links, reach
+	case linkChain:
+		for i := 1; i < n; i++ {
+			links = append(links, link{i - 1, i, linkDistance(pos[i-1], pos[i])})
+		}
+	case linkTree:
+		links = spanningTree(pos)
+	case linkNeighbours:
+		links = nearestNeighbours(pos, linkNeighbourK)
+	}
+	longest := 1.0
+	for _, l := range links {
+		longest = math.Max(longest, l.dist)
+	}
+	return links, longest
+}
+
+// spanningTree is Prim's algorithm on the complete graph; the troupe is small
+// enough that O(n²) is nothing.
+func spanningTree(pos []vector) []link {
+	n := len(pos)
+	inTree := make([]bool, n)
+	best := make([]float64, n)
+	parent := make([]int, n)
+	for i := range best {
+		best[i] = math.Inf(1)
+	}
+	best[0] = 0
+	links := make([]link, 0, n-1)
+	for range pos {
+		u := -1
+		for i := 0; i < n; i++ {
+			if !inTree[i] && (u < 0 || best[i] < best[u]) {
+				u = i
+			}
+		}
+		inTree[u] = true
+		if u != 0 {
+			links = append(links, link{parent[u], u, best[u]})
+		}
+		for v := 0; v < n; v++ {
+			if d := linkDistance(pos[u], pos[v]); !inTree[v] && d < best[v] {
+				best[v] = d
+				parent[v] = u
+			}
+		}
+	}
+	return links
+}
+
+// nearestNeighbours links each follower to its k closest peers, a cheap
+// stand-in for a Delaunay mesh that reads much the same at this density.
+func nearestNeighbours(pos []vector, k int) []link {
+	seen := make(map[[2]int]bool)
+	var links []link
+	for i := range pos {
+		peers := make([]link, 0, len(pos)-1)
+		for j := range pos {
+			if i != j {
+				peers = append(peers, link{i, j, linkDistance(pos[i], pos[j])})
+			}
+		}
+		sort.Slice(peers, func(a, b int) bool { return peers[a].dist < peers[b].dist })
+		for _, l := range peers[:min(k, len(peers))] {
+			key := [2]int{min(l.a, l.b), max(l.a, l.b)}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
+// paintConstellation rasterises each link with Bresenham steps, picking a
+// box-drawing glyph from the line's slope. Short links glow with the top of
+// the palette and long ones fade toward the background.
+func (m *model) paintConstellation(canvas [][]cell, theme moodTheme) {
+	if m.constellation == linkOff {
+		return
+	}
+	links, longest := m.constellationLinks()
+	for _, l := range links {
+		a, b := m.followers[l.a].pos, m.followers[l.b].pos
+		t := clamp(l.dist/longest, 0, 1)
+		fg := blendHex(theme.colorAt(0.95-t*0.6), theme.background, t*0.55)
+		glyph := slopeGlyph(b.x-a.x, b.y-a.y)
+		x0, y0 := int(math.Round(a.x)), int(math.Round(a.y))
+		x1, y1 := int(math.Round(b.x)), int(math.Round(b.y))
+		dx, dy := abs(x1-x0), -abs(y1-y0)
+		sx, sy := 1, 1
+		if x0 > x1 {
+			sx = -1
+		}
+		if y0 > y1 {
+			sy = -1
+		}
+		err := dx + dy
+		for {
+			if x0 >= 0 && y0 >= 0 && x0 < m.canvasWidth && y0 < m.canvasHeight && canvas[y0][x0].priority < 1 {
+				canvas[y0][x0] = cell{ch: glyph, fg: fg, bg: canvas[y0][x0].bg, priority: 1}
+			}
+			if x0 == x1 && y0 == y1 {
+				break
+			}
+			e2 := 2 * err
+			if e2 >= dy {
+				err += dy
+				x0 += sx
+			}
+			if e2 <= dx {
+				err += dx
+				y0 += sy
+			}
+		}
+	}
+}
+
+func slopeGlyph(dx, dy float64) rune {
+	angle := math.Atan2(dy*2, dx) // aspect-corrected, radians
+	a := math.Mod(angle+math.Pi, math.Pi)
+	switch {
+	case a < math.Pi/8 || a >= 7*math.Pi/8:
+		return '─'
+	case a < 3*math.Pi/8:
+		return '╲'
+	case a < 5*math.Pi/8:
+		return '│'
+	default:
+		return '╱'
+	}
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// exportConfig comes from the command line and is shared by every pane.
+type exportConfig struct {
+	window  float64 // seconds captured before the export writes itself
+	formats []string
+	animate bool
+	dir     string
+}
+
+var defaultExportConfig = exportConfig{window: 10, formats: []string{"svg"}, dir: "."}
+
+func parseExportFormats(list string) ([]string, error) {
+	var formats []string
+	for _, f := range strings.Split(list, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		switch f {
+		case "":
+			continue
+		case "svg", "json":
+			formats = append(formats, f)
+		default:
+			return nil, fmt.Errorf("unknown export format %q (want svg or json)", f)
+		}
+	}
+	if len(formats) == 0 {
+		return nil, fmt.Errorf("no export formats given")
+	}
+	return formats, nil
+}
+
+type stamp struct {
+	t, x, y float64
+}
+
+type capturedPath struct {
+	order       int
+	paletteSeed float64
+	points      []stamp
+}
+
+// trailCapture records every follower's position each frame so the light
+// painting can be written out as vector art once the window closes.
+type trailCapture struct {
+	start float64
+	paths []capturedPath
+	byID  map[int]int // follower id to its entry in paths
+}
+
+// toggleCapture starts a capture, or finishes the running one early.
+func (m *model) toggleCapture() {
+	if m.capture != nil {
+		m.finishCapture()
+		return
+	}
+	m.capture = &trailCapture{start: m.t, byID: map[int]int{}}
+	m.flash(fmt.Sprintf("capturing trails for %.0fs (e to stop early)", m.export.window))
+}
+
+func (m *model) recordCapture() {
+	c := m.capture
+	if c == nil {
+		return
+	}
+	elapsed := m.t - c.start
+	// Paths are keyed by follower id, so a follower added after a removal
+	// starts its own path rather than carrying on the removed one's.
+	for _, f := range m.followers {
+		i, ok := c.byID[f.id]
+		if !ok {
+			i = len(c.paths)
+			c.byID[f.id] = i
+			c.paths = append(c.paths, capturedPath{order: f.order, paletteSeed: f.paletteSeed})
+		}
+		c.paths[i].points = append(c.paths[i].points, stamp{elapsed, f.pos.x, f.pos.y})
+	}
+	if elapsed >= m.export.window {
+		m.finishCapture()
+	}
+}
+
+func (m *model) finishCapture() {
+	c := m.capture
+	m.capture = nil
+	written, err := writeCapture(c, m.export, m.currentMood(), m.canvasWidth, m.canvasHeight, m.t-c.start)
+	if err != nil {
+		m.flash("export failed: " + err.Error())
+		return
+	}
+	m.flash("exported " + strings.Join(written, ", "))
+}
+
+func writeCapture(c *trailCapture, cfg exportConfig, theme moodTheme, width, height int, duration float64) ([]string, error) {
+	if err := os.MkdirAll(cfg.dir, 0o755); err != nil {
+		return nil, err
+	}
+	base := exportBase(cfg, time.Now())
+	var written []string
+	for _, format := range cfg.formats {
+		var data []byte
+		var err error
+		switch format {
+		case "svg":
+			data = []byte(captureSVG(c, theme, width, height, duration, cfg.animate))
+		case "json":
+			data, err = captureJSON(c, theme, width, height, duration)
+		default:
+			err = fmt.Errorf("unknown export format %q", format)
+		}
+		if err != nil {
+			return written, err
+		}
+		path := base + "." + format
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return written, err
+		}
+		written = append(written, path)
+	}
+	return written, nil
+}
+
+// exportBase picks a file name stem no earlier export used, adding -2, -3
+// and so on when several exports land in the same second.
+func exportBase(cfg exportConfig, now time.Time) string {
+	stem := filepath.Join(cfg.dir, "harmonic-garden-"+now.Format("20060102-150405"))
+	base := stem
+	for n := 2; ; n++ {
+		taken := false
+		for _, format := range cfg.formats {
+			if _, err := os.Stat(base + "." + format); err == nil {
+				taken = true
+			}
+		}
+		if !taken {
+			return base
+		}
+		base = fmt.Sprintf("%s-%d", stem, n)
+	}
+}
+
+func pathColor(theme moodTheme, p capturedPath) string {
+	return theme.colorAt(clamp(0.5+p.paletteSeed*0.45, 0, 1))
+}
+
+// svgAspect stretches y so the drawing keeps the on-screen proportions of
+// cells roughly twice as tall as they are wide.
+const svgAspect = 2
+
+// captureSVG draws one polyline per follower over the mood background. With
+// animate set, each line draws itself in over the capture's duration using
+// SMIL on a normalised dash.
+func captureSVG(c *trailCapture, theme moodTheme, width, height int, duration float64, animate bool) string {
+	var b strings.Builder
+	w, h := float64(width), float64(height)*svgAspect
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.0f %.0f" width="%.0f" height="%.0f">`+"\n", w, h, w*8, h*8)
+	fmt.Fprintf(&b, `  <title>harmonic garden · %s</title>`+"\n", theme.name)
+	fmt.Fprintf(&b, `  <rect width="100%%" height="100%%" fill="%s"/>`+"\n", theme.background)
+	b.WriteString(`  <g fill="none" stroke-width="0.35" stroke-linecap="round" stroke-linejoin="round">` + "\n")
+	for _, p := range c.paths {
+		if len(p.points) < 2 {
+			continue
+		}
+		coords := make([]string, len(p.points))
+		for i, s := range p.points {
+			coords[i] = fmt.Sprintf("%.2f,%.2f", s.x, s.y*svgAspect)
+		}
+		fmt.Fprintf(&b, `    <polyline stroke="%s" points="%s"`, pathColor(theme, p), strings.Join(coords, " "))
+		if !animate {
+			b.WriteString("/>\n")
+			continue
+		}
+		b.WriteString(` pathLength="1" stroke-dasharray="1" stroke-dashoffset="1">` + "\n")
+		fmt.Fprintf(&b, `      <animate attributeName="stroke-dashoffset" from="1" to="0" dur="%.2fs" begin="%.2fs" fill="freeze" repeatCount="indefinite"/>`+"\n",
+			math.Max(duration, 0.1), p.points[0].t)
+		b.WriteString("    </polyline>\n")
+	}
+	b.WriteString("  </g>\n</svg>\n")
+	return b.String()
+}
+
+type jsonCapture struct {
+	Width     int          `json:"width"`
+	Height    int          `json:"height"`
+	Duration  float64      `json:"duration"`
+	Mood      string       `json:"mood"`
+	Followers []jsonFollow `json:"followers"`
+}
+
+type jsonFollow struct {
+	Order  int          `json:"order"`
+	Color  string       `json:"color"`
+	Points [][3]float64 `json:"points"` // [t, x, y] in seconds and cells
+}
+
+func captureJSON(c *trailCapture, theme moodTheme, width, height int, duration float64) ([]byte, error) {
+	out := jsonCapture{Width: width, Height: height, Duration: round2(duration), Mood: theme.name}
+	for _, p := range c.paths {
+		f := jsonFollow{Order: p.order, Color: pathColor(theme, p)}
+		for _, s := range p.points {
+			f.Points = append(f.Points, [3]float64{round2(s.t), round2(s.x), round2(s.y)})
+		}
+		out.Followers = append(out.Followers, f)
+	}
+	// Point lists get long; indenting them would triple the file size.
+	data, err := json.Marshal(out)
+	return append(data, '\n'), err
+}
+
+func round2(v float64) float64 {
+	return math.Round(v*100) / 100
+}
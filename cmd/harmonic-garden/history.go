@@ -0,0 +1,204 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	maxHistory = 100
+	// Repeated nudges of the same continuous parameter within this many
+	// seconds fold into a single edit, so holding ' does not flood the
+	// history.
+	coalesceWindow = 1.0
+	historyRows    = 8
+)
+
+type editKind int
+
+const (
+	editFreq editKind = iota
+	editDamping
+	editMuses
+	editScene
+	editFormation
+	editMood
+)
+
+var editKindNames = []string{"freq", "damping", "muses", "scene", "formation", "mood"}
+
+type change struct {
+	kind          editKind
+	before, after float64
+}
+
+type edit struct {
+	changes []change
+	at      float64
+}
+
+// history is a linear undo stack; edits past cursor are redoable until a new
+// edit truncates them.
+type history struct {
+	edits  []edit
+	cursor int
+}
+
+// gardenParams is the slice of state the history tracks.
+type gardenParams [6]float64
+
+func (m *model) params() gardenParams {
+	return gardenParams{
+		editFreq:      m.freq,
+		editDamping:   m.damping,
+		editMuses:     float64(len(m.followers)),
+		editScene:     float64(m.sceneIndex),
+		editFormation: float64(indexOfFormation(m.formation)),
+		editMood:      float64(m.moodIndex),
+	}
+}
+
+// recordEdit compares the state against a snapshot taken before a key, OSC
+// message or controller was handled and pushes whatever changed as one
+// undoable edit.
+func (m *model) recordEdit(before gardenParams) {
+	after := m.params()
+	var changes []change
+	for k := range before {
+		if before[k] != after[k] {
+			changes = append(changes, change{editKind(k), before[k], after[k]})
+		}
+	}
+	if len(changes) == 0 {
+		return
+	}
+	h := &m.history
+	h.edits = h.edits[:h.cursor]
+	if n := len(h.edits); n > 0 && m.t-h.edits[n-1].at < coalesceWindow && coalesces(h.edits[n-1].changes, changes) {
+		last := &h.edits[n-1]
+		last.changes[0].after = changes[0].after
+		last.at = m.t
+		if last.changes[0].before == last.changes[0].after {
+			h.edits = h.edits[:n-1]
+		}
+		h.cursor = len(h.edits)
+		return
+	}
+	h.edits = append(h.edits, edit{changes: changes, at: m.t})
+	if len(h.edits) > maxHistory {
+		h.edits = h.edits[len(h.edits)-maxHistory:]
+	}
+	h.cursor = len(h.edits)
+}
+
+// coalesces reports whether b continues the nudge recorded in a. Only single
+// changes to freq, damping or troupe size merge; cycling scenes or moods stays
+// one step per press.
+func coalesces(a, b []change) bool {
+	if len(a) != 1 || len(b) != 1 || a[0].kind != b[0].kind {
+		return false
+	}
+	switch a[0].kind {
+	case editFreq, editDamping, editMuses:
+		return true
+	}
+	return false
+}
+
+func (m *model) undo() {
+	h := &m.history
+	if h.cursor == 0 {
+		m.flash("nothing to undo")
+		return
+	}
+	h.cursor--
+	e := h.edits[h.cursor]
+	for _, c := range e.changes {
+		m.setParam(c.kind, c.before)
+	}
+	m.flash("undo " + e.label())
+}
+
+func (m *model) redo() {
+	h := &m.history
+	if h.cursor >= len(h.edits) {
+		m.flash("nothing to redo")
+		return
+	}
+	e := h.edits[h.cursor]
+	h.cursor++
+	for _, c := range e.changes {
+		m.setParam(c.kind, c.after)
+	}
+	m.flash("redo " + e.label())
+}
+
+func (m *model) setParam(kind editKind, v float64) {
+	switch kind {
+	case editFreq:
+		m.freq = clamp(v, minFrequency, maxFrequency)
+		m.retuneFollowers()
+	case editDamping:
+		m.damping = clamp(v, minDamping, maxDamping)
+		m.retuneFollowers()
+	case editMuses:
+		n := int(v)
+		for len(m.followers) < n && len(m.followers) < maxFollowers {
+			m.addFollower()
+		}
+		for len(m.followers) > n && len(m.followers) > 3 {
+			m.removeFollower()
+		}
+	case editScene:
+		m.sceneIndex = wrapIndex(int(v), len(scenes))
+	case editFormation:
+		m.formation = formations[wrapIndex(int(v), len(formations))].id
+	case editMood:
+		m.moodIndex = wrapIndex(int(v), len(moods))
+	}
+}
+
+func (c change) String() string {
+	switch c.kind {
+	case editFreq, editDamping:
+		return fmt.Sprintf("%s %.2f → %.2f", editKindNames[c.kind], c.before, c.after)
+	case editScene:
+		return fmt.Sprintf("scene %s → %s", scenes[int(c.before)].name, scenes[int(c.after)].name)
+	case editFormation:
+		return fmt.Sprintf("formation %s → %s", formations[int(c.before)].name, formations[int(c.after)].name)
+	case editMood:
+		return fmt.Sprintf("mood %s → %s", moods[int(c.before)].name, moods[int(c.after)].name)
+	default:
+		return fmt.Sprintf("%s %.0f → %.0f", editKindNames[c.kind], c.before, c.after)
+	}
+}
+
+func (e edit) label() string {
+	parts := make([]string, len(e.changes))
+	for i, c := range e.changes {
+		parts[i] = c.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// historyLines lists the most recent edits, newest last. Edits that have
+// been undone stay visible, greyed out, until something new replaces them.
+func (m *model) historyLines() []panelLine {
+	h := m.history
+	lines := []panelLine{{{"history", roleTitle}, {"  u undo  U redo  H close", roleDim}}, nil}
+	if len(h.edits) == 0 {
+		return append(lines, panelLine{{"no edits yet", roleDim}})
+	}
+	start := max(len(h.edits)-historyRows, 0)
+	for i := start; i < len(h.edits); i++ {
+		switch {
+		case i >= h.cursor:
+			lines = append(lines, panelLine{{fmt.Sprintf("↷ %3d  %s", i+1, h.edits[i].label()), roleDim}})
+		case i == h.cursor-1:
+			lines = append(lines, panelLine{{fmt.Sprintf("▸ %3d  %s", i+1, h.edits[i].label()), roleActive}})
+		default:
+			lines = append(lines, textLine("  %3d  %s", i+1, h.edits[i].label()))
+		}
+	}
+	return lines
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/midi"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/osc"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func readyModel(t *testing.T) model {
+	t.Helper()
+	next, _ := newModel().Update(tea.WindowSizeMsg{Width: 80, Height: 40})
+	return next.(model)
+}
+
+func press(m model, k string) model {
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return next.(model)
+}
+
+// TestOutsideEditsUndo checks that changes arriving by OSC, MIDI controller
+// and preset load are undone like keyed ones.
+func TestOutsideEditsUndo(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	presets, _ := json.Marshal([]preset{{Name: "calm", Freq: 3, Damping: 0.6, Muses: 5, Mood: 2}})
+	path := filepath.Join(dir, "charm-experiments", "harmonic-garden-presets.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, presets, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		apply func(m model) model
+	}{
+		{"osc", func(m model) model {
+			m.applyOSC(oscMsg{osc.Message{Address: "/garden/freq", Args: []any{float32(12)}}})
+			return m
+		}},
+		{"midi", func(m model) model {
+			m.playMIDI([]midi.Event{{Kind: midi.ControlChange, Key: ccDamping, Value: 127}}, true)
+			return m
+		}},
+		{"preset", func(m model) model { return press(m, "p") }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := readyModel(t)
+			before := m.params()
+			m = tt.apply(m)
+			if m.params() == before {
+				t.Fatal("nothing changed")
+			}
+			if len(m.history.edits) != 1 {
+				t.Fatalf("history has %d edits, want 1", len(m.history.edits))
+			}
+			m = press(m, "u")
+			if got := m.params(); got != before {
+				t.Errorf("after undo params are %v, want %v", got, before)
+			}
+		})
+	}
+}
+
+// TestSplitOSCRecordsOnBothSides checks that a remote pane edit lands in the
+// pane's history and a shared scene change in the parent's.
+func TestSplitOSCRecordsOnBothSides(t *testing.T) {
+	m := readyModel(t)
+	m.cycleSplit()
+	if len(m.panes) == 0 {
+		t.Fatal("split did not make panes")
+	}
+	m.applyOSC(oscMsg{osc.Message{Address: "/garden/freq", Args: []any{float32(12)}}})
+	m.applyOSC(oscMsg{osc.Message{Address: "/garden/scene", Args: []any{int32(1)}}})
+	if n := len(m.focused().history.edits); n != 1 {
+		t.Errorf("pane history has %d edits, want 1", n)
+	}
+	if n := len(m.history.edits); n != 1 {
+		t.Errorf("parent history has %d edits, want 1", n)
+	}
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/charmbracelet/harmonica"
+)
+
+// personalitySpreads are the steps the scatter key walks through. Zero gives
+// every follower the shared springs.
+var personalitySpreads = []float64{0, 0.25, 0.5, 1}
+
+const (
+	maxFreqOffset    = 4.0
+	maxDampingOffset = 0.6
+)
+
+type inspectField int
+
+const (
+	inspectFreq inspectField = iota
+	inspectDamping
+	inspectRadius
+	inspectSpeed
+	inspectFieldCount
+)
+
+var inspectFieldNames = []string{"freq offset", "damping offset", "radius", "speed"}
+
+// tune rebuilds the follower's springs from the shared parameters plus its own
+// personality offsets.
+func (f *follower) tune(freq, damping float64) {
+	f.freq = clamp(freq+f.freqOffset, minFrequency, maxFrequency)
+	f.damping = clamp(damping+f.dampingOffset, minDamping, maxDamping)
+	f.springX = harmonica.NewSpring(harmonica.FPS(fps), f.freq, f.damping)
+	f.springY = harmonica.NewSpring(harmonica.FPS(fps), f.freq, f.damping)
+}
+
+func (f *follower) scatter(spread float64, rng *rand.Rand) {
+	f.freqOffset = (rng.Float64()*2 - 1) * spread * maxFreqOffset
+	f.dampingOffset = (rng.Float64()*2 - 1) * spread * maxDampingOffset
+}
+
+// cycleSpread re-rolls every follower's personality at the next spread step.
+func (m *model) cycleSpread() {
+	m.spreadIndex = (m.spreadIndex + 1) % len(personalitySpreads)
+	spread := personalitySpreads[m.spreadIndex]
+	for _, f := range m.followers {
+		f.scatter(spread, m.rng)
+	}
+	m.retuneFollowers()
+}
+
+func (m *model) selectedFollower() *follower {
+	if len(m.followers) == 0 {
+		return nil
+	}
+	m.inspected = wrapIndex(m.inspected, len(m.followers))
+	return m.followers[m.inspected]
+}
+
+// updateInspectKey routes editing keys to the inspector while it is open,
+// reporting false for keys it leaves alone.
+func (m *model) updateInspectKey(msg string) bool {
+	switch msg {
+	case "up", "k":
+		m.inspected--
+		m.selectedFollower()
+	case "down", "j":
+		m.inspected++
+		m.selectedFollower()
+	case "left", "h":
+		m.inspectField = inspectField(wrapIndex(int(m.inspectField)-1, int(inspectFieldCount)))
+	case "right", "l":
+		m.inspectField = inspectField(wrapIndex(int(m.inspectField)+1, int(inspectFieldCount)))
+	case "+", "=":
+		m.adjustInspected(1)
+	case "-", "_":
+		m.adjustInspected(-1)
+	case "esc":
+		m.panel = panelNone
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *model) adjustInspected(delta float64) {
+	f := m.selectedFollower()
+	if f == nil {
+		return
+	}
+	switch m.inspectField {
+	case inspectFreq:
+		f.freqOffset = clamp(f.freqOffset+delta*0.25, -maxFreqOffset, maxFreqOffset)
+	case inspectDamping:
+		f.dampingOffset = clamp(f.dampingOffset+delta*0.02, -maxDampingOffset, maxDampingOffset)
+	case inspectRadius:
+		f.radius = clamp(f.radius+delta*0.5, 1, 60)
+	case inspectSpeed:
+		f.speed = clamp(f.speed+delta*0.05, 0, 4)
+	}
+	freq, damping := m.springParams()
+	f.tune(freq, damping)
+}
+
+func (m *model) inspectorLines() []panelLine {
+	f := m.selectedFollower()
+	if f == nil {
+		return []panelLine{{{"no muses to inspect", roleDim}}}
+	}
+	values := []string{
+		fmt.Sprintf("%+.2f", f.freqOffset),
+		fmt.Sprintf("%+.2f", f.dampingOffset),
+		fmt.Sprintf("%.1f", f.radius),
+		fmt.Sprintf("%.2f", f.speed),
+	}
+	fields := panelLine{}
+	for i, v := range values {
+		if i > 0 {
+			fields = append(fields, span{"  ", roleText})
+		}
+		label := fmt.Sprintf("%s %s", inspectFieldNames[i], v)
+		if inspectField(i) == m.inspectField {
+			fields = append(fields, span{"[" + label + "]", roleActive})
+			continue
+		}
+		fields = append(fields, span{label, roleText})
+	}
+	speed := math.Hypot(f.vel.x, f.vel.y)
+	return []panelLine{
+		{{"inspector", roleTitle}, {fmt.Sprintf("  muse %d of %d  ↑/↓ select  ←/→ field  +/- edit  i close", f.order+1, len(m.followers)), roleDim}},
+		nil,
+		fields,
+		textLine("spring %.2f Hz  damping %.2f  velocity (%+.1f, %+.1f) |%.1f|  spread %.2f", f.freq, f.damping, f.vel.x, f.vel.y, speed, personalitySpreads[m.spreadIndex]),
+	}
+}
+
+// paintInspected rings the selected follower's head so it stands out from the
+// troupe while the inspector is open.
+func (m *model) paintInspected(canvas [][]cell, theme moodTheme) {
+	if m.panel != panelInspector {
+		return
+	}
+	f := m.selectedFollower()
+	if f == nil {
+		return
+	}
+	x := int(math.Round(f.pos.x))
+	y := int(math.Round(f.pos.y))
+	if x < 0 || y < 0 || x >= m.canvasWidth || y >= m.canvasHeight {
+		return
+	}
+	canvas[y][x] = cell{ch: '◉', fg: theme.background, bg: theme.accent, bold: true, priority: 6}
+	for _, d := range []struct {
+		dx, dy int
+		ch     rune
+	}{{-1, 0, '['}, {1, 0, ']'}} {
+		nx, ny := x+d.dx, y+d.dy
+		if nx < 0 || nx >= m.canvasWidth {
+			continue
+		}
+		canvas[ny][nx] = cell{ch: d.ch, fg: theme.accent, bg: canvas[ny][nx].bg, bold: true, priority: 6}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"math"
 	"math/rand"
@@ -8,6 +9,10 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ThomasVuNguyen/charm-experiments/internal/audio"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/midi"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/stylecache"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/tempo"
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
@@ -29,12 +34,11 @@ const (
 )
 
 var (
-	frameStyle   = lipgloss.NewStyle().Foreground(lipgloss.Color("213"))
-	infoTitle    = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
-	infoValue    = lipgloss.NewStyle().Foreground(lipgloss.Color("111"))
-	statusStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("57")).Padding(0, 1)
-	bannerStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("213")).Bold(true)
-	helpBoxStyle = lipgloss.NewStyle().Padding(1, 2).Foreground(lipgloss.Color("230")).Background(lipgloss.Color("54"))
+	frameStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("213"))
+	infoTitle   = lipgloss.NewStyle().Foreground(lipgloss.Color("205")).Bold(true)
+	infoValue   = lipgloss.NewStyle().Foreground(lipgloss.Color("111"))
+	statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("230")).Background(lipgloss.Color("57")).Padding(0, 1)
+	bannerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("213")).Bold(true)
 )
 
 type autopScene int
@@ -86,6 +90,26 @@ type keyMap struct {
 	MoveSouth       key.Binding
 	MoveWest        key.Binding
 	MoveEast        key.Binding
+	TapTempo        key.Binding
+	TempoUp         key.Binding
+	TempoDown       key.Binding
+	ToggleSync      key.Binding
+	ToggleMods      key.Binding
+	Inspect         key.Binding
+	Scatter         key.Binding
+	Constellation   key.Binding
+	Split           key.Binding
+	FocusPane       key.Binding
+	Export          key.Binding
+	Undo            key.Binding
+	Redo            key.Binding
+	ToggleHistory   key.Binding
+	Settings        key.Binding
+	BrowsePresets   key.Binding
+	CycleShader     key.Binding
+	CycleSim        key.Binding
+	SavePreset      key.Binding
+	NextPreset      key.Binding
 	ToggleHelp      key.Binding
 }
 
@@ -106,6 +130,26 @@ func newKeyMap() keyMap {
 		MoveSouth:       key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "drift south")),
 		MoveWest:        key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←/h", "drift west")),
 		MoveEast:        key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→/l", "drift east")),
+		TapTempo:        key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "tap tempo")),
+		TempoUp:         key.NewBinding(key.WithKeys("}"), key.WithHelp("}", "bpm +")),
+		TempoDown:       key.NewBinding(key.WithKeys("{"), key.WithHelp("{", "bpm -")),
+		ToggleSync:      key.NewBinding(key.WithKeys("b"), key.WithHelp("b", "beat lock")),
+		ToggleMods:      key.NewBinding(key.WithKeys("o"), key.WithHelp("o", "modulation")),
+		Inspect:         key.NewBinding(key.WithKeys("i"), key.WithHelp("i", "inspect muse")),
+		Scatter:         key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "scatter springs")),
+		Constellation:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "constellation")),
+		Split:           key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "split 1/2/4")),
+		FocusPane:       key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "focus pane")),
+		Export:          key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "export trails")),
+		Undo:            key.NewBinding(key.WithKeys("u", "ctrl+z"), key.WithHelp("u", "undo")),
+		Redo:            key.NewBinding(key.WithKeys("U", "ctrl+y"), key.WithHelp("U", "redo")),
+		ToggleHistory:   key.NewBinding(key.WithKeys("H"), key.WithHelp("H", "history")),
+		Settings:        key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "settings")),
+		BrowsePresets:   key.NewBinding(key.WithKeys("L"), key.WithHelp("L", "preset browser")),
+		CycleShader:     key.NewBinding(key.WithKeys("v"), key.WithHelp("v", "next shader")),
+		CycleSim:        key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "simulation")),
+		SavePreset:      key.NewBinding(key.WithKeys("P"), key.WithHelp("P", "save preset")),
+		NextPreset:      key.NewBinding(key.WithKeys("p"), key.WithHelp("p", "next preset")),
 		ToggleHelp:      key.NewBinding(key.WithKeys("?", "/"), key.WithHelp("?", "toggle help")),
 	}
 }
@@ -116,10 +160,14 @@ func (k keyMap) ShortHelp() []key.Binding {
 
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.ToggleMode, k.CycleScene, k.CycleFormation, k.CycleMood},
+		{k.ToggleMode, k.CycleScene, k.CycleFormation, k.CycleMood, k.CycleShader, k.CycleSim, k.Constellation},
 		{k.IncreaseFreq, k.DecreaseFreq, k.IncreaseDamping, k.DecreaseDamping},
 		{k.MoveNorth, k.MoveSouth, k.MoveWest, k.MoveEast},
-		{k.AddFollower, k.RemoveFollower, k.ToggleHelp, k.Quit},
+		{k.TapTempo, k.TempoUp, k.TempoDown, k.ToggleSync},
+		{k.ToggleMods, k.Settings, k.SavePreset, k.NextPreset, k.BrowsePresets, k.Export},
+		{k.AddFollower, k.RemoveFollower, k.Inspect, k.Scatter},
+		{k.Undo, k.Redo, k.ToggleHistory},
+		{k.Split, k.FocusPane, k.ToggleHelp, k.Quit},
 	}
 }
 
@@ -133,7 +181,8 @@ type moodTheme struct {
 	trailGlyphs  []rune
 	seedGlyph    rune
 	seedInterval float64
-	shader       shaderFunc
+	shader       string
+	physics      seedPhysics
 }
 
 func (m moodTheme) colorAt(t float64) string {
@@ -163,6 +212,7 @@ type formationMeta struct {
 }
 
 type follower struct {
+	id          int // unique for the garden's lifetime, unlike order
 	order       int
 	pos         vector
 	vel         vector
@@ -174,15 +224,26 @@ type follower struct {
 	trace       []vector
 	springX     harmonica.Spring
 	springY     harmonica.Spring
+
+	// Personality offsets sit on top of the shared spring parameters;
+	// freq and damping record what the springs were last built with.
+	freqOffset    float64
+	dampingOffset float64
+	freq          float64
+	damping       float64
 }
 
 type seed struct {
-	projector *harmonica.Projectile
-	pos       vector
-	life      float64
-	ttl       float64
-	color     string
-	glyph     rune
+	projector  *harmonica.Projectile
+	pos        vector
+	life       float64
+	ttl        float64
+	color      string
+	glyph      rune
+	windOffset float64
+	drift      float64
+	grounded   bool
+	settled    bool
 }
 
 type model struct {
@@ -191,11 +252,14 @@ type model struct {
 	canvasWidth  int
 	canvasHeight int
 
-	ready      bool
-	autop      bool
-	sceneIndex int
-	formation  formationMode
-	moodIndex  int
+	ready       bool
+	autop       bool
+	sceneIndex  int
+	formation   formationMode
+	moodIndex   int
+	shaderIndex int
+	simIndex    int
+	sim         simulation
 
 	freq    float64
 	damping float64
@@ -203,14 +267,56 @@ type model struct {
 	t         float64
 	target    vector
 	followers []*follower
+	nextID    int
 	seeds     []*seed
+	sparks    []spark
+	flowers   []flower
 	seedTimer float64
 	rng       *rand.Rand
+	clock     tempo.Clock
+
+	audio        *audio.Analyzer
+	react        audio.Frame
+	tunedFreq    float64
+	tunedDamping float64
+
+	midi          *midi.Player
+	midiFollowers bool
+
+	mods  modMatrix
+	panel panelKind
+
+	inspected     int
+	inspectField  inspectField
+	spreadIndex   int
+	constellation linkMode
+
+	// panes hold the sub-gardens of split-screen mode; the parent keeps
+	// driving the shared target and clock.
+	panes        []*model
+	focus        int
+	splitIndex   int
+	pendingMuses int
+
+	capture *trailCapture
+	export  exportConfig
+
+	history      history
+	settingRow   settingRow
+	presetCursor int
+
+	shaderT     float64
+	presets     []preset
+	presetIndex int
+	presetName  string
+	notice      string
+	noticeUntil float64
 
 	keys       keyMap
 	help       help.Model
 	showHelp   bool
-	styleCache map[string]lipgloss.Style
+	styleCache *stylecache.Cache
+	cacheStats bool
 }
 
 var (
@@ -225,6 +331,7 @@ var (
 			trailGlyphs:  []rune{'.', '*', '+', 'o'},
 			seedGlyph:    '*',
 			seedInterval: 0.28,
+			shader:       "wisps",
 		},
 		{
 			name:         "Cosmic Tie-Dye",
@@ -236,7 +343,17 @@ var (
 			trailGlyphs:  []rune{'.', '*', 'o', '+'},
 			seedGlyph:    '~',
 			seedInterval: 0.26,
-			shader:       tieDyeShader,
+			shader:       "tie-dye",
+			physics: seedPhysics{
+				gravity:      16,
+				wind:         10,
+				bounce:       0.6,
+				friction:     0.8,
+				burst:        7,
+				plantChance:  0.8,
+				flowerGlyphs: []rune{'❀', '✿', '☮'},
+				flowerLife:   16,
+			},
 		},
 		{
 			name:         "Solar Garden",
@@ -248,6 +365,17 @@ var (
 			trailGlyphs:  []rune{'.', '+', '*', 'x'},
 			seedGlyph:    '+',
 			seedInterval: 0.35,
+			shader:       "plasma",
+			physics: seedPhysics{
+				gravity:      24,
+				wind:         3,
+				bounce:       0.3,
+				friction:     0.5,
+				burst:        8,
+				plantChance:  0.5,
+				flowerGlyphs: []rune{'✺', '*', '✹'},
+				flowerLife:   9,
+			},
 		},
 		{
 			name:         "Deep Current",
@@ -259,6 +387,17 @@ var (
 			trailGlyphs:  []rune{'.', ':', '*', 'o'},
 			seedGlyph:    '*',
 			seedInterval: 0.24,
+			shader:       "flow noise",
+			physics: seedPhysics{
+				gravity:      9,
+				wind:         9,
+				bounce:       0.2,
+				friction:     0.9,
+				burst:        4,
+				plantChance:  0.7,
+				flowerGlyphs: []rune{'°', 'o', '✧'},
+				flowerLife:   14,
+			},
 		},
 	}
 
@@ -279,9 +418,68 @@ var (
 )
 
 func main() {
+	audioPath := flag.String("audio", "", "WAV file to react to, or - for raw s16le PCM on stdin")
+	sampleRate := flag.Int("rate", 44100, "sample rate of raw PCM on stdin")
+	channels := flag.Int("channels", 2, "channel count of raw PCM on stdin")
+	bpm := flag.Float64("bpm", 0, "lock scenes and seed emission to this tempo")
+	midiPath := flag.String("midi", "", "Standard MIDI file whose notes throw seeds")
+	midiFollowers := flag.Bool("midi-followers", false, "let MIDI notes add and remove followers")
+	oscAddr := flag.String("osc", "", "listen for OSC messages on this UDP port or address")
+	cacheStats := flag.Bool("cache-stats", false, "show style cache size and hit rate in the footer")
+	exportWindow := flag.Float64("export-window", defaultExportConfig.window, "seconds of trails captured per export")
+	exportFormat := flag.String("export-format", "svg", "comma-separated export formats: svg, json")
+	exportAnimate := flag.Bool("export-animate", false, "animate exported SVG trails with SMIL")
+	exportDir := flag.String("export-dir", ".", "directory exported trails are written to")
+	flag.Parse()
+
 	rand.Seed(time.Now().UnixNano())
 	m := newModel()
-	p := tea.NewProgram(m, tea.WithAltScreen())
+	m.cacheStats = *cacheStats
+	formats, err := parseExportFormats(*exportFormat)
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	m.export = exportConfig{
+		window:  math.Max(*exportWindow, 1),
+		formats: formats,
+		animate: *exportAnimate,
+		dir:     *exportDir,
+	}
+	if *bpm > 0 {
+		m.clock = tempo.New(*bpm)
+		m.clock.Locked = true
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	if *audioPath != "" {
+		analyzer, err := loadAnalyzer(*audioPath, *sampleRate, *channels)
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		m.audio = analyzer
+		if *audioPath == "-" {
+			opts = append(opts, tea.WithInputTTY())
+		}
+	}
+	if *midiPath != "" {
+		player, err := loadPlayer(*midiPath)
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		m.midi = player
+		m.midiFollowers = *midiFollowers
+	}
+	p := tea.NewProgram(m, opts...)
+	if *oscAddr != "" {
+		srv, err := serveOSC(p, *oscAddr)
+		if err != nil {
+			fmt.Println("error:", err)
+			os.Exit(1)
+		}
+		defer srv.Close()
+	}
 	if _, err := p.Run(); err != nil {
 		fmt.Println("error:", err)
 		os.Exit(1)
@@ -291,16 +489,22 @@ func main() {
 func newModel() model {
 	keys := newKeyMap()
 	return model{
-		freq:       7.2,
-		damping:    0.22,
-		autop:      true,
-		sceneIndex: 0,
-		formation:  formationHalo,
-		moodIndex:  0,
-		keys:       keys,
-		help:       help.New(),
-		rng:        rand.New(rand.NewSource(time.Now().UnixNano())),
-		styleCache: make(map[string]lipgloss.Style),
+		freq:        7.2,
+		damping:     0.22,
+		autop:       true,
+		sceneIndex:  0,
+		formation:   formationHalo,
+		moodIndex:   0,
+		keys:        keys,
+		help:        help.New(),
+		rng:         rand.New(rand.NewSource(time.Now().UnixNano())),
+		clock:       tempo.New(tempo.DefaultBPM),
+		mods:        newModMatrix(),
+		presetIndex: -1,
+		shaderIndex: -1,
+		simIndex:    -1,
+		styleCache:  stylecache.New(stylecache.DefaultCapacity),
+		export:      defaultExportConfig,
 	}
 }
 
@@ -329,26 +533,101 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.ready = true
 		}
+		m.layoutPanes()
 		return m, nil
 	case tea.KeyMsg:
 		return m.updateKey(msg)
+	case oscMsg:
+		m.applyOSC(msg)
+		return m, nil
 	case frameMsg:
 		if !m.ready {
 			return m, tick()
 		}
-		m.t += deltaTime
-		if m.autop {
-			m.updateTarget()
-		}
-		m.updateFollowers()
-		m.updateSeeds()
+		m.advance()
 		return m, tick()
 	default:
 		return m, nil
 	}
 }
 
+// advance steps the garden by one frame. While split, the parent only moves
+// the shared target and clock and hands the rest to its panes.
+func (m *model) advance() {
+	clock := m.clock
+	m.t += deltaTime
+	m.clock.Advance(deltaTime)
+
+	// The analyser is stateful and the player consumes events, so both are
+	// read once here and handed to whichever gardens are showing.
+	var frame audio.Frame
+	if m.audio != nil {
+		frame = m.audio.At(m.t)
+	}
+	var events []midi.Event
+	if m.midi != nil {
+		events = m.midi.Advance(m.t)
+	}
+
+	if len(m.panes) > 0 {
+		m.react = frame
+		if m.autop {
+			m.updateTarget()
+		}
+		m.stepPanes(clock, frame, events)
+		return
+	}
+	m.step(frame, events, true)
+}
+
+// step runs one frame of a garden on audio and MIDI already read for it.
+// Controllers only retune it when tune is set.
+func (m *model) step(frame audio.Frame, events []midi.Event, tune bool) {
+	m.listen(frame)
+	m.playMIDI(events, tune)
+	m.mods.step(deltaTime, m.rng)
+	m.shaderT += deltaTime * m.mods.scale(modShaderSpeed)
+	m.syncSprings()
+	if m.autop {
+		m.updateTarget()
+	}
+	m.updateFollowers()
+	m.recordCapture()
+	m.updateSeeds()
+	m.stepSimulation()
+}
+
 func (m model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// The help sheet is modal: while it is up, only closing it or quitting
+	// does anything.
+	if m.showHelp {
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+		case key.Matches(msg, m.keys.ToggleHelp), msg.String() == "esc":
+			m.showHelp = false
+		}
+		return m, nil
+	}
+	if m.updatePaneKey(msg) {
+		return m, nil
+	}
+	switch {
+	case key.Matches(msg, m.keys.Undo):
+		m.undo()
+		return m, nil
+	case key.Matches(msg, m.keys.Redo):
+		m.redo()
+		return m, nil
+	case key.Matches(msg, m.keys.ToggleHistory):
+		m.togglePanel(panelHistory)
+		return m, nil
+	}
+	before := m.params()
+	if m.updatePanelKey(msg) {
+		m.recordEdit(before)
+		return m, nil
+	}
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
@@ -361,6 +640,10 @@ func (m model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.formation = formations[idx].id
 	case key.Matches(msg, m.keys.CycleMood):
 		m.moodIndex = (m.moodIndex + 1) % len(moods)
+	case key.Matches(msg, m.keys.CycleShader):
+		m.cycleShader()
+	case key.Matches(msg, m.keys.CycleSim):
+		m.cycleSimulation()
 	case key.Matches(msg, m.keys.AddFollower):
 		m.addFollower()
 	case key.Matches(msg, m.keys.RemoveFollower):
@@ -381,12 +664,98 @@ func (m model) updateKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.manualTarget(-1, 0)
 	case key.Matches(msg, m.keys.MoveEast):
 		m.manualTarget(1, 0)
+	case key.Matches(msg, m.keys.TapTempo):
+		m.clock.Tap(float64(time.Now().UnixNano()) / float64(time.Second))
+	case key.Matches(msg, m.keys.TempoUp):
+		m.clock.Nudge(1)
+	case key.Matches(msg, m.keys.TempoDown):
+		m.clock.Nudge(-1)
+	case key.Matches(msg, m.keys.ToggleSync):
+		m.clock.Locked = !m.clock.Locked
+	case key.Matches(msg, m.keys.ToggleMods):
+		m.togglePanel(panelMods)
+	case key.Matches(msg, m.keys.Inspect):
+		m.togglePanel(panelInspector)
+	case key.Matches(msg, m.keys.Settings):
+		m.togglePanel(panelSettings)
+	case key.Matches(msg, m.keys.BrowsePresets):
+		m.togglePanel(panelPresets)
+	case key.Matches(msg, m.keys.Split):
+		m.cycleSplit()
+	case key.Matches(msg, m.keys.FocusPane):
+		if len(m.panes) > 0 {
+			m.focus = (m.focus + 1) % len(m.panes)
+		}
+	case key.Matches(msg, m.keys.Export):
+		m.toggleCapture()
+	case key.Matches(msg, m.keys.Constellation):
+		m.cycleConstellation()
+		m.flash("constellation " + linkModeNames[m.constellation])
+	case key.Matches(msg, m.keys.Scatter):
+		m.cycleSpread()
+		m.flash(fmt.Sprintf("spring spread %.2f", personalitySpreads[m.spreadIndex]))
+	case key.Matches(msg, m.keys.SavePreset):
+		m.savePreset()
+	case key.Matches(msg, m.keys.NextPreset):
+		m.nextPreset()
 	case key.Matches(msg, m.keys.ToggleHelp):
 		m.showHelp = !m.showHelp
 	}
+	m.recordEdit(before)
 	return m, nil
 }
 
+// updatePanelKey hands keys to the open panel first. Panels report false for
+// keys they do not use so those keep their usual meaning.
+func (m *model) updatePanelKey(msg tea.KeyMsg) bool {
+	switch m.panel {
+	case panelMods:
+		return m.updateModKey(msg)
+	case panelInspector:
+		return m.updateInspectKey(msg.String())
+	case panelSettings:
+		return m.updateSettingsKey(msg.String())
+	case panelPresets:
+		return m.updatePresetKey(msg.String())
+	case panelHistory:
+		if msg.String() == "esc" {
+			m.panel = panelNone
+			return true
+		}
+	}
+	return false
+}
+
+// updateModKey routes editing keys to the modulation panel while it is open.
+// It reports false for keys the panel does not use so they keep their usual
+// meaning.
+func (m *model) updateModKey(msg tea.KeyMsg) bool {
+	switch msg.String() {
+	case "up", "k":
+		m.mods.moveSelection(-1)
+	case "down", "j":
+		m.mods.moveSelection(1)
+	case "left", "h":
+		m.mods.moveField(-1)
+	case "right", "l":
+		m.mods.moveField(1)
+	case "+", "=":
+		m.mods.adjust(1)
+	case "-", "_":
+		m.mods.adjust(-1)
+	case "esc":
+		m.panel = panelNone
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *model) flash(notice string) {
+	m.notice = notice
+	m.noticeUntil = m.t + 3
+}
+
 func indexOfFormation(f formationMode) int {
 	for i, meta := range formations {
 		if meta.id == f {
@@ -437,6 +806,36 @@ func (m *model) currentMood() moodTheme {
 	return moods[m.moodIndex]
 }
 
+// activeShader is the manually chosen shader, or the mood's own default while
+// shaderIndex is -1.
+func (m *model) activeShader(theme moodTheme) shaderEntry {
+	if m.shaderIndex >= 0 {
+		return shaders[m.shaderIndex]
+	}
+	return shaders[indexOfShader(theme.shader)]
+}
+
+func (m *model) backgroundName(theme moodTheme) string {
+	if m.sim != nil {
+		return simulations[m.simIndex].name
+	}
+	return m.activeShader(theme).name
+}
+
+// cycleShader steps through the registry starting after the mood default and
+// wraps back to following the mood once every shader has had a turn.
+func (m *model) cycleShader() {
+	start := indexOfShader(m.currentMood().shader)
+	if m.shaderIndex < 0 {
+		m.shaderIndex = (start + 1) % len(shaders)
+		return
+	}
+	m.shaderIndex = (m.shaderIndex + 1) % len(shaders)
+	if m.shaderIndex == start {
+		m.shaderIndex = -1
+	}
+}
+
 func (m *model) updateTarget() {
 	if m.canvasWidth == 0 || m.canvasHeight == 0 {
 		return
@@ -446,36 +845,40 @@ func (m *model) updateTarget() {
 	h := float64(m.canvasHeight)
 	cx := w / 2
 	cy := h / 2
+	t := m.sceneTime()
 
 	switch scene {
 	case sceneOrbit:
 		a := w * 0.35
 		b := h * 0.28
 		speed := 0.55
-		m.target.x = cx + math.Cos(m.t*speed)*a + math.Cos(m.t*0.9)*w*0.05
-		m.target.y = cy + math.Sin(m.t*speed*1.2)*b + math.Sin(m.t*0.77)*h*0.04
+		m.target.x = cx + math.Cos(t*speed)*a + math.Cos(t*0.9)*w*0.05
+		m.target.y = cy + math.Sin(t*speed*1.2)*b + math.Sin(t*0.77)*h*0.04
 	case sceneRose:
 		k := 5.0
-		theta := m.t * 0.8
-		radius := (0.4 + 0.15*math.Sin(m.t*0.6)) * math.Sin(k*theta)
+		theta := t * 0.8
+		radius := (0.4 + 0.15*math.Sin(t*0.6)) * math.Sin(k*theta)
 		r := radius * w
 		m.target.x = cx + r*math.Cos(theta)
 		m.target.y = cy + r*math.Sin(theta)
 	case sceneCascade:
-		slow := math.Sin(m.t * 0.3)
-		sway := math.Sin(m.t * 1.8)
-		drift := math.Sin(m.t*0.5 + sway*0.4)
+		slow := math.Sin(t * 0.3)
+		sway := math.Sin(t * 1.8)
+		drift := math.Sin(t*0.5 + sway*0.4)
 		m.target.x = cx + drift*w*0.25
-		m.target.y = cy + ((1+slow)/2)*h*0.35 + math.Sin(m.t*1.2)*h*0.06
+		m.target.y = cy + ((1+slow)/2)*h*0.35 + math.Sin(t*1.2)*h*0.06
 	case scenePulse:
-		theta := m.t * 1.3
-		pulse := (math.Sin(m.t*2.4) + 1) / 2
-		radius := w * (0.18 + 0.28*pulse)
+		theta := t * 1.3
+		pulse := (math.Sin(t*2.4) + 1) / 2
+		if m.clock.Locked {
+			pulse = m.clock.Pulse()
+		}
+		radius := w * (0.18 + 0.28*pulse + m.pulseBoost())
 		m.target.x = cx + radius*math.Cos(theta)
 		m.target.y = cy + radius*0.7*math.Sin(theta*1.4)
 	case sceneWander:
-		n1 := perlin2(m.t*0.15, 0.0)
-		n2 := perlin2(0.0, m.t*0.12+3.7)
+		n1 := perlin2(t*0.15, 0.0)
+		n2 := perlin2(0.0, t*0.12+3.7)
 		m.target.x = cx + n1*w*0.4
 		m.target.y = cy + n2*h*0.35
 	}
@@ -490,8 +893,9 @@ func (m *model) updateFollowers() {
 	}
 	stageW := float64(m.canvasWidth)
 	stageH := float64(m.canvasHeight)
+	spread := m.mods.scale(modRadius)
 	for _, f := range m.followers {
-		f.step(m.target, m.formation, stageW, stageH, m.t, deltaTime, count, mood)
+		f.step(m.target, m.formation, stageW, stageH, m.t, deltaTime, count, spread, mood)
 	}
 }
 
@@ -502,18 +906,24 @@ func (m *model) updateSeeds() {
 	if stageW == 0 || stageH == 0 {
 		return
 	}
-	m.seedTimer += deltaTime
-	if m.seedTimer >= mood.seedInterval {
-		m.emitSeed(mood)
-		m.seedTimer = math.Mod(m.seedTimer, mood.seedInterval)
+	if m.clock.Locked {
+		if m.clock.Crossed(m.clock.Quantize(m.seedInterval(mood))) {
+			m.emitSeed(mood)
+		}
+	} else {
+		m.seedTimer += deltaTime
+		if interval := m.seedInterval(mood); m.seedTimer >= interval {
+			m.emitSeed(mood)
+			m.seedTimer = math.Mod(m.seedTimer, interval)
+		}
 	}
 
+	phys := mood.seedPhysics()
 	alive := m.seeds[:0]
 	for _, s := range m.seeds {
-		pos := s.projector.Update()
-		s.pos = vector{pos.X, pos.Y}
-		s.life += deltaTime
+		m.stepSeed(s, phys, stageH)
 		if s.life >= s.ttl {
+			m.seedDied(s, phys)
 			continue
 		}
 		if s.pos.x < -2 || s.pos.y < -2 || s.pos.x > stageW+2 || s.pos.y > stageH+2 {
@@ -522,6 +932,7 @@ func (m *model) updateSeeds() {
 		alive = append(alive, s)
 	}
 	m.seeds = alive
+	m.updateParticles(phys)
 }
 
 func (m *model) emitSeed(theme moodTheme) {
@@ -532,10 +943,17 @@ func (m *model) emitSeed(theme moodTheme) {
 		X: (m.rng.Float64()*2 - 1) * 14,
 		Y: -6 - m.rng.Float64()*6,
 	}
+	m.launchSeed(theme, velocity, theme.colorAt(m.rng.Float64()))
+}
+
+func (m *model) launchSeed(theme moodTheme, velocity harmonica.Vector, hue string) {
+	if m.canvasWidth == 0 || m.canvasHeight == 0 {
+		return
+	}
 	start := harmonica.Point{X: m.target.x, Y: m.target.y}
-	projectile := harmonica.NewProjectile(harmonica.FPS(fps), start, velocity, harmonica.Vector{X: 0, Y: 18})
+	gravity := harmonica.Vector{X: 0, Y: theme.seedPhysics().gravity}
+	projectile := harmonica.NewProjectile(harmonica.FPS(fps), start, velocity, gravity)
 	ttl := 1.4 + m.rng.Float64()*0.9
-	hue := theme.colorAt(m.rng.Float64())
 	m.seeds = append(m.seeds, &seed{
 		projector: projectile,
 		ttl:       ttl,
@@ -558,7 +976,12 @@ func (m *model) addFollower() {
 	if len(m.followers) >= maxFollowers {
 		return
 	}
-	follower := newFollower(len(m.followers), m.freq, m.damping, m.rng)
+	freq, damping := m.springParams()
+	follower := newFollower(len(m.followers), freq, damping, m.rng)
+	follower.id = m.nextID
+	m.nextID++
+	follower.scatter(personalitySpreads[m.spreadIndex], m.rng)
+	follower.tune(freq, damping)
 	follower.pos = m.target
 	follower.trace = append(follower.trace, m.target)
 	m.followers = append(m.followers, follower)
@@ -572,10 +995,11 @@ func (m *model) removeFollower() {
 }
 
 func (m *model) retuneFollowers() {
+	freq, damping := m.springParams()
 	for _, f := range m.followers {
-		f.springX = harmonica.NewSpring(harmonica.FPS(fps), m.freq, m.damping)
-		f.springY = harmonica.NewSpring(harmonica.FPS(fps), m.freq, m.damping)
+		f.tune(freq, damping)
 	}
+	m.tunedFreq, m.tunedDamping = freq, damping
 }
 
 func newFollower(order int, freq, damping float64, rng *rand.Rand) *follower {
@@ -585,7 +1009,7 @@ func newFollower(order int, freq, damping float64, rng *rand.Rand) *follower {
 	phase := rng.Float64() * 2 * math.Pi
 	paletteSeed := rng.Float64()
 	offsetSeed := rng.Float64()
-	return &follower{
+	f := &follower{
 		order:       order,
 		radius:      radius,
 		speed:       speed,
@@ -593,22 +1017,23 @@ func newFollower(order int, freq, damping float64, rng *rand.Rand) *follower {
 		paletteSeed: paletteSeed,
 		offsetSeed:  offsetSeed,
 		trace:       make([]vector, 0, maxTrail),
-		springX:     harmonica.NewSpring(harmonica.FPS(fps), freq, damping),
-		springY:     harmonica.NewSpring(harmonica.FPS(fps), freq, damping),
 	}
+	f.tune(freq, damping)
+	return f
 }
 
-func (f *follower) step(target vector, formation formationMode, stageW, stageH, t, dt float64, count int, mood moodTheme) {
+func (f *follower) step(target vector, formation formationMode, stageW, stageH, t, dt float64, count int, spread float64, mood moodTheme) {
 	if count < 1 {
 		count = 1
 	}
+	radius := f.radius * spread
 	var offset vector
 	switch formation {
 	case formationHalo:
 		f.phase = math.Mod(f.phase+f.speed*dt, 2*math.Pi)
 		ellipse := 0.55 + 0.25*math.Sin(t*0.8+float64(f.order)*0.3)
-		offset.x = math.Cos(f.phase) * f.radius * ellipse
-		offset.y = math.Sin(f.phase) * f.radius * 0.6 * ellipse
+		offset.x = math.Cos(f.phase) * radius * ellipse
+		offset.y = math.Sin(f.phase) * radius * 0.6 * ellipse
 	case formationRibbon:
 		wave := math.Sin(t*1.4 + float64(f.order)*0.7)
 		offset.x = -float64(f.order) * (1.9 + 0.4*math.Sin(t*0.6))
@@ -618,10 +1043,10 @@ func (f *follower) step(target vector, formation formationMode, stageW, stageH,
 	case formationBloom:
 		petalCount := 3 + (f.order % 5)
 		bloom := (math.Sin(t*0.7+float64(petalCount)) + 1) / 2
-		radius := f.radius * (0.6 + 0.5*bloom)
+		petalRadius := radius * (0.6 + 0.5*bloom)
 		f.phase = math.Mod(f.phase+f.speed*dt*1.2, 2*math.Pi)
-		offset.x = math.Cos(f.phase*float64(petalCount)) * radius
-		offset.y = math.Sin(f.phase*float64(petalCount)) * radius * 0.6
+		offset.x = math.Cos(f.phase*float64(petalCount)) * petalRadius
+		offset.y = math.Sin(f.phase*float64(petalCount)) * petalRadius * 0.6
 	case formationHelix:
 		depth := (float64(f.order) / float64(count-1)) - 0.5
 		helixRadius := stageW * 0.16
@@ -651,52 +1076,64 @@ func (m model) View() string {
 		return "harmonica is tuning resonances..."
 	}
 
-	mood := m.currentMood()
-	canvas := m.prepareCanvas(mood)
-	m.paintTrails(canvas, mood)
-	m.paintSeeds(canvas, mood)
-	m.paintTarget(canvas, mood)
+	var canvas [][]cell
+	if len(m.panes) > 0 {
+		canvas = m.paintPanes()
+	} else {
+		canvas = m.paintStage()
+	}
+	if lines := m.overlayLines(); lines != nil {
+		composite(canvas, lines)
+	}
 
 	var builder strings.Builder
-	for y := 0; y < m.canvasHeight; y++ {
-		row := canvas[y]
-		for x := 0; x < m.canvasWidth; x++ {
-			builder.WriteString(m.renderCell(row[x]))
+	for _, row := range canvas {
+		for _, c := range row {
+			builder.WriteString(m.renderCell(c))
 		}
 		builder.WriteRune('\n')
 	}
-
 	builder.WriteString(m.renderFooter())
-	if m.showHelp {
-		helper := m.help
-		helper.ShowAll = true
-		builder.WriteString("\n")
-		builder.WriteString(helpBoxStyle.Render(helper.View(m.keys)))
-	}
 	return builder.String()
 }
 
+// paintStage paints every layer of the garden into a fresh canvas.
+func (m *model) paintStage() [][]cell {
+	mood := m.currentMood()
+	canvas := m.prepareCanvas(mood)
+	m.paintFlowers(canvas, mood)
+	m.paintConstellation(canvas, mood)
+	m.paintTrails(canvas, mood)
+	m.paintSeeds(canvas, mood)
+	m.paintSparks(canvas, mood)
+	m.paintTarget(canvas, mood)
+	m.paintInspected(canvas, mood)
+	return canvas
+}
+
 func (m *model) prepareCanvas(theme moodTheme) [][]cell {
 	canvas := make([][]cell, m.canvasHeight)
+	glow := m.moodIntensity()
+	shade := m.activeShader(theme).fn
+	highlight := theme.colorAt(0.9)
 	for y := 0; y < m.canvasHeight; y++ {
 		row := make([]cell, m.canvasWidth)
 		for x := 0; x < m.canvasWidth; x++ {
-			wav := math.Sin(float64(x)*0.11+m.t*0.35) + math.Cos(float64(y)*0.09-m.t*0.21+float64(x)*0.03)
-			intensity := (wav + 2) / 4
-			glyph := theme.wispGlyphs[int(intensity*float64(len(theme.wispGlyphs)))%len(theme.wispGlyphs)]
-			fg := theme.colorAt(0.15 + intensity*0.35)
-			bg := theme.background
-			if theme.shader != nil {
-				sGlyph, sFG, sBG := theme.shader(float64(x), float64(y), m.t, m.canvasWidth, m.canvasHeight, theme)
-				if sGlyph != 0 {
-					glyph = sGlyph
-				}
-				if sFG != "" {
-					fg = sFG
-				}
-				if sBG != "" {
-					bg = sBG
-				}
+			var glyph rune
+			var fg, bg string
+			if m.sim != nil {
+				glyph, fg, bg = m.sim.render(x, y, theme)
+			} else {
+				glyph, fg, bg = shade(float64(x), float64(y), m.shaderT, m.canvasWidth, m.canvasHeight, theme)
+			}
+			if glyph == 0 {
+				glyph = ' '
+			}
+			if bg == "" {
+				bg = theme.background
+			}
+			if glow > 0 && fg != "" {
+				fg = blendHex(fg, highlight, glow*0.5)
 			}
 			row[x] = cell{
 				ch:       glyph,
@@ -771,50 +1208,45 @@ func (m *model) paintTarget(canvas [][]cell, theme moodTheme) {
 	canvas[ty][tx] = cell
 }
 
-func tieDyeShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
-	if width == 0 || height == 0 {
-		return 0, "", ""
-	}
-	cx := float64(width-1) / 2
-	cy := float64(height-1) / 2
-	dx := (x - cx) / float64(width)
-	dy := (y - cy) / float64(height)
-	radius := math.Sqrt(dx*dx + dy*dy)
-	angle := math.Atan2(dy, dx)
-	swirl := radius*18 + angle*6 - t*1.4
-	wave := (math.Sin(swirl) + 1) / 2
-	petals := math.Sin(angle*8 + t*0.9)
-	mix := math.Mod(wave*0.7+radius*0.5+petals*0.2, 1)
-	if mix < 0 {
-		mix += 1
-	}
-	fg := theme.colorAt(mix)
-	bgMix := clamp(wave*0.6+0.2, 0, 1)
-	bg := blendHex(fg, theme.background, 1-bgMix)
-	var glyph rune
-	if len(theme.wispGlyphs) > 0 {
-		idx := int(math.Mod(math.Abs(petals)*float64(len(theme.wispGlyphs)), float64(len(theme.wispGlyphs))))
-		glyph = theme.wispGlyphs[idx]
-	}
-	if glyph == 0 {
-		glyph = '~'
-	}
-	return glyph, fg, bg
-}
-
 func (m *model) renderFooter() string {
+	// Garden parameters come from the focused pane while split.
+	g := m.focused()
 	scene := scenes[m.sceneIndex]
-	formation := formations[indexOfFormation(m.formation)]
-	mood := m.currentMood()
+	formation := formations[indexOfFormation(g.formation)]
+	mood := g.currentMood()
 
 	bits := []string{
 		fmt.Sprintf("%s %s", infoTitle.Render("scene"), infoValue.Render(scene.name)),
 		fmt.Sprintf("%s %s", infoTitle.Render("formation"), infoValue.Render(formation.name)),
 		fmt.Sprintf("%s %s", infoTitle.Render("mood"), infoValue.Render(mood.name)),
+		fmt.Sprintf("%s %s", infoTitle.Render("shader"), infoValue.Render(g.backgroundName(mood))),
 		fmt.Sprintf("%s %s", infoTitle.Render("mode"), infoValue.Render(modeLabel(m.autop))),
-		fmt.Sprintf("%s %.2f", infoTitle.Render("freq"), m.freq),
-		fmt.Sprintf("%s %.2f", infoTitle.Render("damping"), m.damping),
-		fmt.Sprintf("%s %d", infoTitle.Render("muses"), len(m.followers)),
+		fmt.Sprintf("%s %.2f", infoTitle.Render("freq"), g.freq),
+		fmt.Sprintf("%s %.2f", infoTitle.Render("damping"), g.damping),
+		fmt.Sprintf("%s %d", infoTitle.Render("muses"), len(g.followers)),
+		fmt.Sprintf("%s %s", infoTitle.Render("bpm"), m.beatIndicator()),
+	}
+	if len(m.panes) > 0 {
+		bits = append(bits, fmt.Sprintf("%s %d/%d", infoTitle.Render("pane"), m.focus+1, len(m.panes)))
+	}
+	if g.capture != nil {
+		bits = append(bits, fmt.Sprintf("%s %.1fs", infoTitle.Render("rec"), g.t-g.capture.start))
+	}
+	if m.audio != nil {
+		bits = append(bits, fmt.Sprintf("%s %s", infoTitle.Render("audio"), m.audioMeter()))
+	}
+	if g.presetName != "" {
+		bits = append(bits, fmt.Sprintf("%s %s", infoTitle.Render("preset"), infoValue.Render(g.presetName)))
+	}
+	if m.cacheStats {
+		bits = append(bits, fmt.Sprintf("%s %s", infoTitle.Render("cache"), m.styleCache.Stats()))
+	}
+	description := scene.description
+	if m.notice != "" && m.t < m.noticeUntil {
+		description = m.notice
+	}
+	if g.notice != "" && g.t < g.noticeUntil {
+		description = g.notice
 	}
 
 	footer := statusStyle.Render(strings.Join(bits, "  "))
@@ -823,7 +1255,7 @@ func (m *model) renderFooter() string {
 
 	lines := []string{
 		frameStyle.Render(strings.Repeat("─", max(m.canvasWidth, len(footer)))),
-		lipgloss.JoinHorizontal(lipgloss.Left, banner, "  ", scene.description),
+		lipgloss.JoinHorizontal(lipgloss.Left, banner, "  ", description),
 		footer,
 		short,
 	}
@@ -831,22 +1263,7 @@ func (m *model) renderFooter() string {
 }
 
 func (m *model) renderCell(c cell) string {
-	key := fmt.Sprintf("%s|%s|%t", c.fg, c.bg, c.bold)
-	style, ok := m.styleCache[key]
-	if !ok {
-		style = lipgloss.NewStyle()
-		if c.fg != "" {
-			style = style.Foreground(lipgloss.Color(c.fg))
-		}
-		if c.bg != "" {
-			style = style.Background(lipgloss.Color(c.bg))
-		}
-		if c.bold {
-			style = style.Bold(true)
-		}
-		m.styleCache[key] = style
-	}
-	return style.Render(string(c.ch))
+	return m.styleCache.Render(string(c.ch), c.fg, c.bg, c.bold)
 }
 
 func modeLabel(autop bool) string {
@@ -873,6 +1290,13 @@ func max(a, b int) int {
 	return b
 }
 
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
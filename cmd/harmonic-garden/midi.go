@@ -0,0 +1,66 @@
+package main
+
+import (
+	"github.com/ThomasVuNguyen/charm-experiments/internal/midi"
+	"github.com/charmbracelet/harmonica"
+)
+
+const (
+	ccFrequency = 1  // mod wheel
+	ccDamping   = 11 // expression
+	lowestNote  = 21 // A0
+	highestNote = 108
+)
+
+// playMIDI applies the events due this frame. Notes throw seeds coloured by
+// pitch and launched by velocity; with tune set, the mod wheel and
+// expression controllers sweep spring frequency and damping, and a sweep
+// lands in the history as one edit.
+func (m *model) playMIDI(events []midi.Event, tune bool) {
+	mood := m.currentMood()
+	for _, ev := range events {
+		switch ev.Kind {
+		case midi.NoteOn:
+			m.noteSeed(mood, ev.Key, ev.Value)
+			if m.midiFollowers {
+				m.addFollower()
+			}
+		case midi.NoteOff:
+			if m.midiFollowers && len(m.followers) > initialFollowers {
+				m.removeFollower()
+			}
+		case midi.ControlChange:
+			if !tune {
+				continue
+			}
+			v := float64(ev.Value) / 127
+			before := m.params()
+			switch ev.Key {
+			case ccFrequency:
+				m.freq = minFrequency + v*(maxFrequency-minFrequency)
+			case ccDamping:
+				m.damping = minDamping + v*(maxDamping-minDamping)
+			}
+			m.recordEdit(before)
+		}
+	}
+}
+
+func (m *model) noteSeed(theme moodTheme, note, velocity int) {
+	pitch := clamp(float64(note-lowestNote)/float64(highestNote-lowestNote), 0, 1)
+	force := 0.35 + 0.65*float64(velocity)/127
+	direction := pitch*2 - 1
+	velocityVec := harmonica.Vector{
+		X: direction*14*force + (m.rng.Float64()*2-1)*2,
+		Y: -(6 + 10*force),
+	}
+	m.launchSeed(theme, velocityVec, theme.colorAt(pitch))
+}
+
+func loadPlayer(path string) (*midi.Player, error) {
+	song, err := midi.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return midi.NewPlayer(song), nil
+}
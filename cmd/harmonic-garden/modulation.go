@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+)
+
+type lfoShape int
+
+const (
+	lfoSine lfoShape = iota
+	lfoTriangle
+	lfoSquare
+	lfoSampleHold
+	lfoNoise
+)
+
+var lfoShapeNames = []string{"sine", "triangle", "square", "s&h", "noise"}
+
+type modTarget int
+
+const (
+	modFrequency modTarget = iota
+	modDamping
+	modRadius
+	modSeedInterval
+	modShaderSpeed
+)
+
+var modTargetNames = []string{"freq", "damping", "radius", "seed rate", "shader speed"}
+
+const (
+	lfoSlots    = 4
+	minLFORate  = 0.05
+	maxLFORate  = 8.0
+	lfoRateStep = 0.05
+	depthStep   = 0.05
+)
+
+type lfoField int
+
+const (
+	fieldShape lfoField = iota
+	fieldTarget
+	fieldRate
+	fieldDepth
+	fieldCount
+)
+
+// lfo is a low-frequency oscillator routed to one parameter. Depth is the
+// fraction of the parameter's base value it can swing either way.
+type lfo struct {
+	shape  lfoShape
+	target modTarget
+	rate   float64
+	depth  float64
+
+	phase float64
+	held  float64
+	noise float64
+}
+
+func (l *lfo) step(dt float64, rng *rand.Rand) {
+	l.phase += l.rate * dt
+	if l.phase >= 1 {
+		l.phase -= math.Floor(l.phase)
+		l.held = rng.Float64()*2 - 1
+	}
+	l.noise += l.rate * dt
+}
+
+// value is the oscillator output in [-1, 1].
+func (l lfo) value() float64 {
+	p := l.phase
+	switch l.shape {
+	case lfoTriangle:
+		return 1 - 4*math.Abs(p-0.5)
+	case lfoSquare:
+		if p < 0.5 {
+			return 1
+		}
+		return -1
+	case lfoSampleHold:
+		return l.held
+	case lfoNoise:
+		return clamp(perlin2(l.noise, 7.3)*1.4, -1, 1)
+	default:
+		return math.Sin(p * 2 * math.Pi)
+	}
+}
+
+type modMatrix struct {
+	lfos     []lfo
+	selected int
+	field    lfoField
+}
+
+func newModMatrix() modMatrix {
+	return modMatrix{lfos: []lfo{
+		{shape: lfoSine, target: modFrequency, rate: 0.25},
+		{shape: lfoTriangle, target: modDamping, rate: 0.15},
+		{shape: lfoSampleHold, target: modRadius, rate: 0.5},
+		{shape: lfoNoise, target: modShaderSpeed, rate: 0.3},
+	}}
+}
+
+func (mm *modMatrix) step(dt float64, rng *rand.Rand) {
+	for i := range mm.lfos {
+		mm.lfos[i].step(dt, rng)
+	}
+}
+
+// amount sums every LFO routed to target, scaled by depth.
+func (mm modMatrix) amount(target modTarget) float64 {
+	var sum float64
+	for _, l := range mm.lfos {
+		if l.target == target && l.depth > 0 {
+			sum += l.value() * l.depth
+		}
+	}
+	return sum
+}
+
+// scale is the multiplier an LFO sum applies to a base value, floored so a
+// parameter never flips sign.
+func (mm modMatrix) scale(target modTarget) float64 {
+	return math.Max(0.05, 1+mm.amount(target))
+}
+
+func (mm *modMatrix) moveSelection(delta int) {
+	mm.selected = wrapIndex(mm.selected+delta, len(mm.lfos))
+}
+
+func (mm *modMatrix) moveField(delta int) {
+	mm.field = lfoField(wrapIndex(int(mm.field)+delta, int(fieldCount)))
+}
+
+func (mm *modMatrix) adjust(delta int) {
+	if len(mm.lfos) == 0 {
+		return
+	}
+	l := &mm.lfos[mm.selected]
+	switch mm.field {
+	case fieldShape:
+		l.shape = lfoShape(wrapIndex(int(l.shape)+delta, len(lfoShapeNames)))
+	case fieldTarget:
+		l.target = modTarget(wrapIndex(int(l.target)+delta, len(modTargetNames)))
+	case fieldRate:
+		l.rate = clamp(l.rate+float64(delta)*lfoRateStep, minLFORate, maxLFORate)
+	case fieldDepth:
+		l.depth = clamp(l.depth+float64(delta)*depthStep, 0, 1)
+	}
+}
+
+func (mm modMatrix) lines() []panelLine {
+	lines := []panelLine{{{"modulation", roleTitle}, {"  ↑/↓ lfo  ←/→ field  +/- adjust  o close", roleDim}}, nil}
+	for i, l := range mm.lfos {
+		fields := []string{
+			fmt.Sprintf("%-8s", lfoShapeNames[l.shape]),
+			fmt.Sprintf("→ %-12s", modTargetNames[l.target]),
+			fmt.Sprintf("%5.2fHz", l.rate),
+			fmt.Sprintf("depth %3.0f%%", l.depth*100),
+		}
+		marker := "  "
+		if i == mm.selected {
+			marker = "▸ "
+		}
+		meter := meterGlyphs[min(int((l.value()+1)/2*float64(len(meterGlyphs))), len(meterGlyphs)-1)]
+		if l.depth == 0 {
+			meter = '·'
+		}
+		line := panelLine{{fmt.Sprintf("%slfo %d", marker, i+1), roleText}}
+		for f, text := range fields {
+			line = append(line, span{"  ", roleText})
+			if i == mm.selected && lfoField(f) == mm.field {
+				line = append(line, span{"[" + strings.TrimSpace(text) + "]", roleActive})
+				continue
+			}
+			line = append(line, span{text, roleText})
+		}
+		lines = append(lines, append(line, span{"  " + string(meter), roleText}))
+	}
+	return lines
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// panelKind names the modal panel open in a garden. Only one is open at a
+// time; the help sheet is separate because it belongs to the whole screen.
+type panelKind int
+
+const (
+	panelNone panelKind = iota
+	panelMods
+	panelInspector
+	panelHistory
+	panelSettings
+	panelPresets
+)
+
+type spanRole int
+
+const (
+	roleText spanRole = iota
+	roleTitle
+	roleActive
+	roleDim
+)
+
+type span struct {
+	text string
+	role spanRole
+}
+
+// panelLine is one row of an overlay, built from styled spans so the panel
+// can be painted straight into canvas cells.
+type panelLine []span
+
+func textLine(format string, args ...any) panelLine {
+	return panelLine{{fmt.Sprintf(format, args...), roleText}}
+}
+
+func (l panelLine) width() int {
+	n := 0
+	for _, s := range l {
+		n += utf8.RuneCountInString(s.text)
+	}
+	return n
+}
+
+const (
+	overlayBackground = "#1C1230"
+	overlayBorder     = "#FF87D7"
+	overlayDimTo      = "#000000"
+	overlayDimAmount  = 0.6
+	overlayPadding    = 2
+)
+
+var overlayRoleColors = map[spanRole]string{
+	roleText:   "#FFFFD7",
+	roleTitle:  "#FF5FAF",
+	roleActive: "#87AFFF",
+	roleDim:    "#8A8A8A",
+}
+
+func (m *model) togglePanel(p panelKind) {
+	if m.panel == p {
+		m.panel = panelNone
+		return
+	}
+	m.panel = p
+	if p == panelPresets {
+		m.openPresetBrowser()
+	}
+}
+
+// overlayLines returns the panel to draw over the stage, if any.
+func (m *model) overlayLines() []panelLine {
+	if m.showHelp {
+		return m.helpLines()
+	}
+	g := m.focused()
+	switch g.panel {
+	case panelMods:
+		return g.mods.lines()
+	case panelInspector:
+		return g.inspectorLines()
+	case panelHistory:
+		return g.historyLines()
+	case panelSettings:
+		return g.settingsLines()
+	case panelPresets:
+		return g.presetLines()
+	}
+	return nil
+}
+
+// composite dims every canvas cell and paints a bordered panel centred on
+// top of it.
+func composite(canvas [][]cell, lines []panelLine) {
+	height := len(canvas)
+	if height == 0 {
+		return
+	}
+	width := len(canvas[0])
+	for y := range canvas {
+		for x := range canvas[y] {
+			c := &canvas[y][x]
+			if c.fg != "" {
+				c.fg = blendHex(c.fg, overlayDimTo, overlayDimAmount)
+			}
+			if c.bg != "" {
+				c.bg = blendHex(c.bg, overlayDimTo, overlayDimAmount)
+			}
+			c.bold = false
+		}
+	}
+
+	inner := 0
+	for _, l := range lines {
+		inner = max(inner, l.width())
+	}
+	boxW := min(inner+2*overlayPadding+2, width)
+	boxH := min(len(lines)+4, height)
+	left := (width - boxW) / 2
+	top := (height - boxH) / 2
+
+	put := func(x, y int, ch rune, fg string, bold bool) {
+		if x < left || x >= left+boxW || y < top || y >= top+boxH {
+			return
+		}
+		canvas[y][x] = cell{ch: ch, fg: fg, bg: overlayBackground, bold: bold, priority: 10}
+	}
+	for y := top; y < top+boxH; y++ {
+		for x := left; x < left+boxW; x++ {
+			put(x, y, ' ', "", false)
+		}
+	}
+	right, bottom := left+boxW-1, top+boxH-1
+	for x := left + 1; x < right; x++ {
+		put(x, top, '─', overlayBorder, false)
+		put(x, bottom, '─', overlayBorder, false)
+	}
+	for y := top + 1; y < bottom; y++ {
+		put(left, y, '│', overlayBorder, false)
+		put(right, y, '│', overlayBorder, false)
+	}
+	put(left, top, '╭', overlayBorder, false)
+	put(right, top, '╮', overlayBorder, false)
+	put(left, bottom, '╰', overlayBorder, false)
+	put(right, bottom, '╯', overlayBorder, false)
+
+	for i, l := range lines {
+		y := top + 2 + i
+		if y >= bottom {
+			break
+		}
+		x := left + 1 + overlayPadding
+		for _, s := range l {
+			for _, ch := range s.text {
+				if x >= right {
+					break
+				}
+				put(x, y, ch, overlayRoleColors[s.role], s.role == roleTitle)
+				x++
+			}
+		}
+	}
+}
+
+// helpLines lays the full key map out in as many columns as fit.
+func (m *model) helpLines() []panelLine {
+	type entry struct{ key, desc string }
+	var entries []entry
+	keyWidth, descWidth := 0, 0
+	for _, group := range m.keys.FullHelp() {
+		for _, b := range group {
+			h := b.Help()
+			entries = append(entries, entry{h.Key, h.Desc})
+			keyWidth = max(keyWidth, utf8.RuneCountInString(h.Key))
+			descWidth = max(descWidth, utf8.RuneCountInString(h.Desc))
+		}
+	}
+	colWidth := keyWidth + descWidth + 4
+	cols := clampInt((m.canvasWidth-2*overlayPadding-2)/colWidth, 1, 3)
+	rows := (len(entries) + cols - 1) / cols
+
+	lines := []panelLine{{{"keys", roleTitle}, {"  ? or esc to close", roleDim}}, nil}
+	for r := 0; r < rows; r++ {
+		var line panelLine
+		for c := 0; c < cols; c++ {
+			i := c*rows + r
+			if i >= len(entries) {
+				break
+			}
+			e := entries[i]
+			line = append(line,
+				span{fmt.Sprintf("%-*s ", keyWidth, e.key), roleActive},
+				span{fmt.Sprintf("%-*s   ", descWidth, e.desc), roleText},
+			)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func clampInt(v, lo, hi int) int {
+	return max(lo, min(v, hi))
+}
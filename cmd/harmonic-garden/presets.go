@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type lfoPreset struct {
+	Shape  string  `json:"shape"`
+	Target string  `json:"target"`
+	Rate   float64 `json:"rate"`
+	Depth  float64 `json:"depth"`
+}
+
+type preset struct {
+	Name      string      `json:"name"`
+	Freq      float64     `json:"freq"`
+	Damping   float64     `json:"damping"`
+	Scene     int         `json:"scene"`
+	Formation int         `json:"formation"`
+	Mood      int         `json:"mood"`
+	Muses     int         `json:"muses"`
+	Shader    string      `json:"shader,omitempty"`
+	LFOs      []lfoPreset `json:"lfos,omitempty"`
+}
+
+func presetPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "charm-experiments", "harmonic-garden-presets.json"), nil
+}
+
+func loadPresets() ([]preset, error) {
+	path, err := presetPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var presets []preset
+	if err := json.Unmarshal(data, &presets); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return presets, nil
+}
+
+func savePresets(presets []preset) error {
+	path, err := presetPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (m *model) capturePreset(name string) preset {
+	p := preset{
+		Name:      name,
+		Freq:      m.freq,
+		Damping:   m.damping,
+		Scene:     m.sceneIndex,
+		Formation: indexOfFormation(m.formation),
+		Mood:      m.moodIndex,
+		Muses:     len(m.followers),
+	}
+	if m.shaderIndex >= 0 {
+		p.Shader = shaders[m.shaderIndex].name
+	}
+	for _, l := range m.mods.lfos {
+		p.LFOs = append(p.LFOs, lfoPreset{
+			Shape:  lfoShapeNames[l.shape],
+			Target: modTargetNames[l.target],
+			Rate:   l.rate,
+			Depth:  l.depth,
+		})
+	}
+	return p
+}
+
+func (m *model) applyPreset(p preset) {
+	m.freq = clamp(p.Freq, minFrequency, maxFrequency)
+	m.damping = clamp(p.Damping, minDamping, maxDamping)
+	m.sceneIndex = wrapIndex(p.Scene, len(scenes))
+	m.formation = formations[wrapIndex(p.Formation, len(formations))].id
+	m.moodIndex = wrapIndex(p.Mood, len(moods))
+	m.shaderIndex = -1
+	if p.Shader != "" {
+		m.shaderIndex = indexOfShader(p.Shader)
+	}
+	for len(m.followers) < p.Muses && len(m.followers) < maxFollowers {
+		m.addFollower()
+	}
+	for len(m.followers) > p.Muses && len(m.followers) > 3 {
+		m.removeFollower()
+	}
+	if len(p.LFOs) > 0 {
+		m.mods.lfos = m.mods.lfos[:0]
+		for _, lp := range p.LFOs {
+			m.mods.lfos = append(m.mods.lfos, lfo{
+				shape:  lfoShape(indexOfName(lfoShapeNames, lp.Shape)),
+				target: modTarget(indexOfName(modTargetNames, lp.Target)),
+				rate:   clamp(lp.Rate, minLFORate, maxLFORate),
+				depth:  clamp(lp.Depth, 0, 1),
+			})
+		}
+		m.mods.selected = 0
+	}
+	m.presetName = p.Name
+	m.retuneFollowers()
+}
+
+// savePreset appends the current state to the preset file.
+func (m *model) savePreset() {
+	presets, err := loadPresets()
+	if err != nil {
+		m.flash("preset load failed: " + err.Error())
+		return
+	}
+	p := m.capturePreset(fmt.Sprintf("preset %d", len(presets)+1))
+	presets = append(presets, p)
+	if err := savePresets(presets); err != nil {
+		m.flash("preset save failed: " + err.Error())
+		return
+	}
+	m.presets = presets
+	m.presetIndex = len(presets) - 1
+	m.presetName = p.Name
+	m.flash("saved " + p.Name)
+}
+
+// nextPreset re-reads the preset file so edits made outside the garden are
+// picked up, then loads the next entry.
+func (m *model) nextPreset() {
+	presets, err := loadPresets()
+	if err != nil {
+		m.flash("preset load failed: " + err.Error())
+		return
+	}
+	m.presets = presets
+	if len(presets) == 0 {
+		m.flash("no presets saved yet (P to save)")
+		return
+	}
+	m.presetIndex = (m.presetIndex + 1) % len(presets)
+	m.applyPreset(presets[m.presetIndex])
+	m.flash("loaded " + m.presetName)
+}
+
+func indexOfName(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// openPresetBrowser re-reads the preset file and puts the cursor on the
+// preset last loaded.
+func (m *model) openPresetBrowser() {
+	presets, err := loadPresets()
+	if err != nil {
+		m.flash("preset load failed: " + err.Error())
+	}
+	m.presets = presets
+	m.presetCursor = clampInt(m.presetIndex, 0, max(len(presets)-1, 0))
+}
+
+// updatePresetKey routes keys to the preset browser while it is open.
+func (m *model) updatePresetKey(msg string) bool {
+	switch msg {
+	case "up", "k":
+		if len(m.presets) > 0 {
+			m.presetCursor = wrapIndex(m.presetCursor-1, len(m.presets))
+		}
+	case "down", "j":
+		if len(m.presets) > 0 {
+			m.presetCursor = wrapIndex(m.presetCursor+1, len(m.presets))
+		}
+	case "enter":
+		if m.presetCursor < len(m.presets) {
+			m.presetIndex = m.presetCursor
+			m.applyPreset(m.presets[m.presetIndex])
+			m.flash("loaded " + m.presetName)
+			m.panel = panelNone
+		}
+	case "esc":
+		m.panel = panelNone
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *model) presetLines() []panelLine {
+	lines := []panelLine{{{"presets", roleTitle}, {"  ↑/↓ pick  enter load  L close", roleDim}}, nil}
+	if len(m.presets) == 0 {
+		return append(lines, panelLine{{"no presets saved yet (P to save)", roleDim}})
+	}
+	for i, p := range m.presets {
+		marker, role := "  ", roleText
+		if i == m.presetCursor {
+			marker, role = "▸ ", roleActive
+		}
+		summary := fmt.Sprintf("  %s · %s · f %.2f d %.2f · %d muses",
+			moods[wrapIndex(p.Mood, len(moods))].name, formations[wrapIndex(p.Formation, len(formations))].name, p.Freq, p.Damping, p.Muses)
+		lines = append(lines, panelLine{{marker + p.Name, role}, {summary, roleDim}})
+	}
+	return lines
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/audio"
+)
+
+const (
+	onsetBurst   = 4
+	minSeedDelay = 0.04
+)
+
+var meterGlyphs = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// listen reacts to a frame of the loaded track, sampled at the animation
+// clock so playback stays in lockstep with the visuals even when frames are
+// dropped.
+func (m *model) listen(frame audio.Frame) {
+	if m.audio == nil {
+		return
+	}
+	m.react = frame
+	if m.react.Onset {
+		for i := 0; i < onsetBurst; i++ {
+			m.emitSeed(m.currentMood())
+		}
+	}
+}
+
+func (m *model) pulseBoost() float64 {
+	if m.audio == nil {
+		return 0
+	}
+	return m.react.Bass * 0.22
+}
+
+func (m *model) seedInterval(theme moodTheme) float64 {
+	interval := theme.seedInterval * m.mods.scale(modSeedInterval)
+	if m.audio != nil {
+		interval *= 1 - 0.75*m.react.Mid
+	}
+	return math.Max(interval, minSeedDelay)
+}
+
+func (m *model) moodIntensity() float64 {
+	if m.audio == nil {
+		return 0
+	}
+	return m.react.Level
+}
+
+func (m *model) springParams() (float64, float64) {
+	freq := m.freq * m.mods.scale(modFrequency)
+	if m.audio != nil {
+		freq *= 1 + 0.6*m.react.Treble
+	}
+	damping := m.damping * m.mods.scale(modDamping)
+	return clamp(freq, minFrequency, maxFrequency), clamp(damping, minDamping, maxDamping)
+}
+
+// syncSprings retunes followers only when the effective parameters drift far
+// enough to matter; rebuilding springs every frame would be wasted work.
+func (m *model) syncSprings() {
+	freq, damping := m.springParams()
+	if math.Abs(freq-m.tunedFreq) > 0.05 || math.Abs(damping-m.tunedDamping) > 0.005 {
+		m.retuneFollowers()
+	}
+}
+
+func (m *model) audioMeter() string {
+	bands := []float64{m.react.Bass, m.react.Mid, m.react.Treble}
+	meter := make([]rune, len(bands))
+	for i, v := range bands {
+		meter[i] = meterGlyphs[min(int(clamp(v, 0, 1)*float64(len(meterGlyphs))), len(meterGlyphs)-1)]
+	}
+	onset := " "
+	if m.react.Onset {
+		onset = "!"
+	}
+	return fmt.Sprintf("%s%s", string(meter), onset)
+}
+
+func loadAnalyzer(path string, rate, channels int) (*audio.Analyzer, error) {
+	track, err := audio.Open(path, rate, channels)
+	if err != nil {
+		return nil, err
+	}
+	return audio.NewAnalyzer(track), nil
+}
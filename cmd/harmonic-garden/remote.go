@@ -0,0 +1,95 @@
+package main
+
+import (
+	"github.com/ThomasVuNguyen/charm-experiments/internal/osc"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type oscMsg struct {
+	osc.Message
+}
+
+// serveOSC forwards every incoming OSC message into the program so remote
+// changes are applied on the Update goroutine like any keypress.
+func serveOSC(p *tea.Program, addr string) (*osc.Server, error) {
+	srv, err := osc.Listen(addr)
+	if err != nil {
+		return nil, err
+	}
+	go srv.Serve(func(msg osc.Message) {
+		p.Send(oscMsg{msg})
+	})
+	return srv, nil
+}
+
+// applyOSC handles the /garden namespace. Target coordinates are normalised
+// to [0, 1] so senders need not know the terminal size.
+func (m *model) applyOSC(msg oscMsg) {
+	// Garden parameters land on the focused pane while split; the target,
+	// autopilot and scene stay shared. Each side records its own changes so
+	// u takes remote edits back like keyed ones.
+	g := m.focused()
+	before, paneBefore := m.params(), g.params()
+	defer func() {
+		m.recordEdit(before)
+		if g != m {
+			g.recordEdit(paneBefore)
+		}
+	}()
+	switch msg.Address {
+	case "/garden/freq":
+		if v, ok := msg.Float(0); ok {
+			g.freq = clamp(v, minFrequency, maxFrequency)
+			g.retuneFollowers()
+		}
+	case "/garden/damping":
+		if v, ok := msg.Float(0); ok {
+			g.damping = clamp(v, minDamping, maxDamping)
+			g.retuneFollowers()
+		}
+	case "/garden/target":
+		x, okX := msg.Float(0)
+		y, okY := msg.Float(1)
+		if okX && okY && m.canvasWidth > 0 && m.canvasHeight > 0 {
+			m.autop = false
+			m.target = vector{clamp(x, 0, 1) * float64(m.canvasWidth-1), clamp(y, 0, 1) * float64(m.canvasHeight-1)}
+			m.clampTarget()
+		}
+	case "/garden/auto":
+		if v, ok := msg.Int(0); ok {
+			m.autop = v != 0
+		}
+	case "/garden/mood":
+		if n, ok := msg.Int(0); ok {
+			g.moodIndex = wrapIndex(n, len(moods))
+		}
+	case "/garden/scene":
+		if n, ok := msg.Int(0); ok {
+			m.sceneIndex = wrapIndex(n, len(scenes))
+		}
+	case "/garden/formation":
+		if n, ok := msg.Int(0); ok {
+			g.formation = formations[wrapIndex(n, len(formations))].id
+		}
+	case "/garden/muses":
+		if n, ok := msg.Int(0); ok {
+			for len(g.followers) < n && len(g.followers) < maxFollowers {
+				g.addFollower()
+			}
+			for len(g.followers) > n && len(g.followers) > 3 {
+				g.removeFollower()
+			}
+		}
+	}
+}
+
+func wrapIndex(n, length int) int {
+	if length == 0 {
+		return 0
+	}
+	n %= length
+	if n < 0 {
+		n += length
+	}
+	return n
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"math"
+
+	"github.com/charmbracelet/harmonica"
+)
+
+const (
+	maxFlowers   = 160
+	maxSparks    = 400
+	settleSpeed  = 2.5
+	sparkGravity = 0.4
+)
+
+// seedPhysics tunes how seeds fly, land and die for a mood.
+type seedPhysics struct {
+	gravity      float64
+	wind         float64 // peak perlin wind speed in cells per second
+	bounce       float64 // vertical restitution on the floor
+	friction     float64 // horizontal speed kept per bounce
+	burst        int     // sparks thrown when a seed expires
+	plantChance  float64 // chance a grounded seed leaves a flower
+	flowerGlyphs []rune
+	flowerLife   float64
+}
+
+var defaultSeedPhysics = seedPhysics{
+	gravity:      18,
+	wind:         6,
+	bounce:       0.45,
+	friction:     0.7,
+	burst:        5,
+	plantChance:  0.6,
+	flowerGlyphs: []rune{'✿', '❀', '*'},
+	flowerLife:   12,
+}
+
+func (t moodTheme) seedPhysics() seedPhysics {
+	if t.physics.gravity == 0 {
+		return defaultSeedPhysics
+	}
+	return t.physics
+}
+
+type spark struct {
+	pos   vector
+	vel   vector
+	life  float64
+	ttl   float64
+	color string
+}
+
+type flower struct {
+	pos   vector
+	glyph rune
+	color string
+	age   float64
+	life  float64
+}
+
+// stepSeed advances one seed. The harmonica projectile still owns the
+// ballistic arc; wind is layered on as a separate drift, and a bounce
+// relaunches the projectile from the floor with reflected velocity.
+func (m *model) stepSeed(s *seed, phys seedPhysics, stageH float64) {
+	s.life += deltaTime
+	if s.settled {
+		return
+	}
+	p := s.projector.Update()
+	wind := perlin2(m.t*0.4+s.pos.y*0.05, 3.1+s.pos.x*0.02) * phys.wind
+	s.drift += (wind - s.drift) * deltaTime * 2
+	s.windOffset += s.drift * deltaTime
+	s.pos = vector{p.X + s.windOffset, p.Y}
+
+	floor := stageH - 1
+	if s.pos.y < floor {
+		return
+	}
+	s.pos.y = floor
+	s.grounded = true
+	v := s.projector.Velocity()
+	if v.Y <= 0 {
+		return
+	}
+	vy := -v.Y * phys.bounce
+	vx := (v.X + s.drift) * phys.friction
+	if math.Abs(vy) < settleSpeed {
+		s.settled = true
+		return
+	}
+	s.projector = harmonica.NewProjectile(harmonica.FPS(fps), harmonica.Point{X: s.pos.x, Y: floor}, harmonica.Vector{X: vx, Y: vy}, harmonica.Vector{X: 0, Y: phys.gravity})
+	s.windOffset = 0
+	s.drift = 0
+}
+
+// seedDied throws a spray of sparks and, for seeds that reached the ground,
+// sometimes plants a flower that lingers long after the seed is gone.
+func (m *model) seedDied(s *seed, phys seedPhysics) {
+	for i := 0; i < phys.burst && len(m.sparks) < maxSparks; i++ {
+		angle := m.rng.Float64() * 2 * math.Pi
+		speed := 4 + m.rng.Float64()*8
+		m.sparks = append(m.sparks, spark{
+			pos:   s.pos,
+			vel:   vector{math.Cos(angle) * speed, math.Sin(angle)*speed*0.5 - 3},
+			ttl:   0.35 + m.rng.Float64()*0.4,
+			color: s.color,
+		})
+	}
+	if !s.grounded || len(phys.flowerGlyphs) == 0 || m.rng.Float64() >= phys.plantChance {
+		return
+	}
+	if len(m.flowers) >= maxFlowers {
+		m.flowers = m.flowers[1:]
+	}
+	m.flowers = append(m.flowers, flower{
+		pos:   vector{math.Round(s.pos.x), s.pos.y},
+		glyph: phys.flowerGlyphs[m.rng.Intn(len(phys.flowerGlyphs))],
+		color: s.color,
+		life:  phys.flowerLife * (0.7 + m.rng.Float64()*0.6),
+	})
+}
+
+func (m *model) updateParticles(phys seedPhysics) {
+	stageW := float64(m.canvasWidth)
+	stageH := float64(m.canvasHeight)
+	live := m.sparks[:0]
+	for _, sp := range m.sparks {
+		sp.life += deltaTime
+		sp.vel.y += phys.gravity * sparkGravity * deltaTime
+		sp.pos.x += sp.vel.x * deltaTime
+		sp.pos.y += sp.vel.y * deltaTime
+		if sp.life >= sp.ttl || sp.pos.x < 0 || sp.pos.x >= stageW || sp.pos.y < 0 || sp.pos.y >= stageH {
+			continue
+		}
+		live = append(live, sp)
+	}
+	m.sparks = live
+
+	blooming := m.flowers[:0]
+	for _, f := range m.flowers {
+		f.age += deltaTime
+		if f.age < f.life && f.pos.y < stageH && f.pos.x < stageW {
+			blooming = append(blooming, f)
+		}
+	}
+	m.flowers = blooming
+}
+
+func (m *model) paintFlowers(canvas [][]cell, theme moodTheme) {
+	for _, f := range m.flowers {
+		x := int(f.pos.x)
+		y := int(f.pos.y)
+		if x < 0 || y < 0 || x >= m.canvasWidth || y >= m.canvasHeight {
+			continue
+		}
+		fade := clamp(f.age/f.life, 0, 1)
+		glyph := f.glyph
+		if fade > 0.75 {
+			glyph = '.'
+		}
+		fg := blendHex(f.color, theme.background, fade*0.85)
+		c := cell{ch: glyph, fg: fg, bg: canvas[y][x].bg, bold: fade < 0.2, priority: 2}
+		if c.priority >= canvas[y][x].priority {
+			canvas[y][x] = c
+		}
+	}
+}
+
+func (m *model) paintSparks(canvas [][]cell, theme moodTheme) {
+	for _, sp := range m.sparks {
+		x := int(math.Round(sp.pos.x))
+		y := int(math.Round(sp.pos.y))
+		if x < 0 || y < 0 || x >= m.canvasWidth || y >= m.canvasHeight {
+			continue
+		}
+		fade := clamp(sp.life/sp.ttl, 0, 1)
+		glyph := '·'
+		if fade < 0.4 {
+			glyph = '+'
+		}
+		fg := blendHex(theme.accent, sp.color, fade)
+		c := cell{ch: glyph, fg: fg, bg: canvas[y][x].bg, priority: 4}
+		if c.priority >= canvas[y][x].priority {
+			canvas[y][x] = c
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+type settingRow int
+
+const (
+	settingFreq settingRow = iota
+	settingDamping
+	settingMuses
+	settingRowCount
+)
+
+const sliderWidth = 24
+
+func slider(v, lo, hi float64) string {
+	filled := int(clamp((v-lo)/(hi-lo), 0, 1)*sliderWidth + 0.5)
+	return strings.Repeat("█", filled) + strings.Repeat("░", sliderWidth-filled)
+}
+
+// updateSettingsKey routes keys to the settings sliders while they are open.
+func (m *model) updateSettingsKey(msg string) bool {
+	switch msg {
+	case "up", "k":
+		m.settingRow = settingRow(wrapIndex(int(m.settingRow)-1, int(settingRowCount)))
+	case "down", "j":
+		m.settingRow = settingRow(wrapIndex(int(m.settingRow)+1, int(settingRowCount)))
+	case "left", "h", "-", "_":
+		m.adjustSetting(-1)
+	case "right", "l", "+", "=":
+		m.adjustSetting(1)
+	case "esc":
+		m.panel = panelNone
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *model) adjustSetting(dir float64) {
+	switch m.settingRow {
+	case settingFreq:
+		m.adjustFrequency(0.35 * dir)
+	case settingDamping:
+		m.adjustDamping(0.05 * dir)
+	case settingMuses:
+		if dir > 0 {
+			m.addFollower()
+		} else {
+			m.removeFollower()
+		}
+	}
+}
+
+func (m *model) settingsLines() []panelLine {
+	rows := []struct {
+		name   string
+		slider string
+		value  string
+	}{
+		{"freq", slider(m.freq, minFrequency, maxFrequency), fmt.Sprintf("%.2f", m.freq)},
+		{"damping", slider(m.damping, minDamping, maxDamping), fmt.Sprintf("%.2f", m.damping)},
+		{"muses", slider(float64(len(m.followers)), 3, maxFollowers), fmt.Sprintf("%d", len(m.followers))},
+	}
+	lines := []panelLine{{{"settings", roleTitle}, {"  ↑/↓ pick  ←/→ adjust  s close", roleDim}}, nil}
+	for i, r := range rows {
+		marker, role := "  ", roleText
+		if settingRow(i) == m.settingRow {
+			marker, role = "▸ ", roleActive
+		}
+		lines = append(lines, panelLine{
+			{fmt.Sprintf("%s%-8s ", marker, r.name), role},
+			{r.slider, role},
+			{" " + r.value, roleText},
+		})
+	}
+	return lines
+}
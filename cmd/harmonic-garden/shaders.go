@@ -0,0 +1,197 @@
+package main
+
+import "math"
+
+type shaderEntry struct {
+	name string
+	fn   shaderFunc
+}
+
+// shaders is the background registry. Moods name their default by entry name
+// and the visuals key cycles through the list in order.
+var shaders = []shaderEntry{
+	{"wisps", wispShader},
+	{"tie-dye", tieDyeShader},
+	{"plasma", plasmaShader},
+	{"voronoi", voronoiShader},
+	{"reaction-diffusion", reactionShader},
+	{"metaballs", metaballShader},
+	{"starfield", starfieldShader},
+	{"flow noise", flowNoiseShader},
+}
+
+func indexOfShader(name string) int {
+	for i, s := range shaders {
+		if s.name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// wispGlyph picks a glyph from the mood's wisp set for an intensity in [0, 1].
+func wispGlyph(theme moodTheme, intensity float64) rune {
+	if len(theme.wispGlyphs) == 0 {
+		return ' '
+	}
+	idx := int(clamp(intensity, 0, 0.9999) * float64(len(theme.wispGlyphs)))
+	return theme.wispGlyphs[idx]
+}
+
+func wispShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	wav := math.Sin(x*0.11+t*0.35) + math.Cos(y*0.09-t*0.21+x*0.03)
+	intensity := (wav + 2) / 4
+	return wispGlyph(theme, intensity), theme.colorAt(0.15 + intensity*0.35), theme.background
+}
+
+func tieDyeShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	if width == 0 || height == 0 {
+		return 0, "", ""
+	}
+	cx := float64(width-1) / 2
+	cy := float64(height-1) / 2
+	dx := (x - cx) / float64(width)
+	dy := (y - cy) / float64(height)
+	radius := math.Sqrt(dx*dx + dy*dy)
+	angle := math.Atan2(dy, dx)
+	swirl := radius*18 + angle*6 - t*1.4
+	wave := (math.Sin(swirl) + 1) / 2
+	petals := math.Sin(angle*8 + t*0.9)
+	mix := math.Mod(wave*0.7+radius*0.5+petals*0.2, 1)
+	if mix < 0 {
+		mix += 1
+	}
+	fg := theme.colorAt(mix)
+	bgMix := clamp(wave*0.6+0.2, 0, 1)
+	bg := blendHex(fg, theme.background, 1-bgMix)
+	var glyph rune
+	if len(theme.wispGlyphs) > 0 {
+		idx := int(math.Mod(math.Abs(petals)*float64(len(theme.wispGlyphs)), float64(len(theme.wispGlyphs))))
+		glyph = theme.wispGlyphs[idx]
+	}
+	if glyph == 0 {
+		glyph = '~'
+	}
+	return glyph, fg, bg
+}
+
+func plasmaShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	// Cells are roughly twice as tall as wide, so y is stretched to keep blobs round.
+	sx := x * 0.08
+	sy := y * 0.16
+	v := math.Sin(sx+t) +
+		math.Sin((sy+t)*0.7) +
+		math.Sin((sx+sy+t)*0.5) +
+		math.Sin(math.Sqrt(sx*sx+sy*sy)*1.3-t*1.2)
+	intensity := (v + 4) / 8
+	fg := theme.colorAt(intensity)
+	bg := blendHex(theme.background, theme.colorAt(1-intensity), 0.25)
+	return wispGlyph(theme, intensity), fg, bg
+}
+
+func voronoiShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	const cellSize = 9.0
+	px := x / cellSize
+	py := y * 2 / cellSize
+	gx := math.Floor(px)
+	gy := math.Floor(py)
+	nearest, second := math.MaxFloat64, math.MaxFloat64
+	var owner float64
+	for oy := -1.0; oy <= 1; oy++ {
+		for ox := -1.0; ox <= 1; ox++ {
+			cx, cy := gx+ox, gy+oy
+			h1 := (hash2(int(cx), int(cy)) + 1) / 2
+			h2 := (hash2(int(cy)+17, int(cx)-31) + 1) / 2
+			fx := cx + 0.5 + 0.4*math.Sin(t*0.6+h1*6.28)
+			fy := cy + 0.5 + 0.4*math.Cos(t*0.5+h2*6.28)
+			d := math.Hypot(px-fx, py-fy)
+			if d < nearest {
+				second = nearest
+				nearest = d
+				owner = h1
+			} else if d < second {
+				second = d
+			}
+		}
+	}
+	edge := second - nearest
+	fg := theme.colorAt(owner)
+	bg := blendHex(theme.background, fg, 0.35)
+	if edge < 0.08 {
+		return '·', theme.accent, theme.background
+	}
+	return wispGlyph(theme, 1-nearest), fg, bg
+}
+
+func reactionShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	// A domain-warped stripe field that settles into the labyrinth look of
+	// Turing patterns without carrying any state between frames.
+	sx := x * 0.12
+	sy := y * 0.24
+	warpX := perlin2(sx*0.35+t*0.05, sy*0.35) * 3
+	warpY := perlin2(sx*0.35+5.2, sy*0.35-t*0.04) * 3
+	v := math.Sin((sx+warpX)*1.7) * math.Cos((sy+warpY)*1.7)
+	v += 0.5 * math.Sin((sx-warpY)*3.1+t*0.2)
+	band := (v + 1.5) / 3
+	if band > 0.52 {
+		return wispGlyph(theme, band), theme.colorAt(0.55 + band*0.4), blendHex(theme.background, theme.colorAt(0.3), 0.4)
+	}
+	return ' ', theme.colorAt(0.1), theme.background
+}
+
+func metaballShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	if width == 0 || height == 0 {
+		return 0, "", ""
+	}
+	w := float64(width)
+	h := float64(height)
+	var field float64
+	for i := 0; i < 5; i++ {
+		fi := float64(i)
+		bx := w/2 + math.Cos(t*(0.3+fi*0.07)+fi*1.9)*w*0.35
+		by := h/2 + math.Sin(t*(0.4+fi*0.05)+fi*2.7)*h*0.35
+		dx := (x - bx) / w
+		dy := (y - by) * 2 / w
+		field += 0.0025 * (1 + 0.3*fi) / (dx*dx + dy*dy + 1e-4)
+	}
+	switch {
+	case field > 1:
+		return wispGlyph(theme, 0.99), theme.accent, theme.colorAt(clamp(field/4, 0.5, 1))
+	case field > 0.6:
+		return wispGlyph(theme, field), theme.colorAt(field), blendHex(theme.background, theme.colorAt(0.5), 0.5)
+	}
+	return ' ', theme.colorAt(0.1), blendHex(theme.background, theme.colorAt(field), field*0.4)
+}
+
+func starfieldShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	if width == 0 || height == 0 {
+		return 0, "", ""
+	}
+	// Three parallax layers drifting left at different speeds.
+	for layer := 0; layer < 3; layer++ {
+		speed := 2.0 + float64(layer)*4
+		sx := int(math.Floor(x + t*speed))
+		n := (hash2(sx*(layer+3), int(y)*(layer+7)+layer*131) + 1) / 2
+		if n > 0.985-float64(layer)*0.004 {
+			twinkle := 0.5 + 0.5*math.Sin(t*3+n*40)
+			glyphs := []rune{'.', '+', '*'}
+			return glyphs[layer], blendHex(theme.colorAt(0.6+0.13*float64(layer)), theme.accent, twinkle), theme.background
+		}
+	}
+	return ' ', theme.colorAt(0.1), theme.background
+}
+
+func flowNoiseShader(x, y, t float64, width, height int, theme moodTheme) (rune, string, string) {
+	n := perlin2(x*0.06+t*0.2, y*0.12-t*0.1)
+	angle := n * math.Pi * 2
+	intensity := (perlin2(x*0.03-t*0.05, y*0.06+t*0.07) + 1) / 2
+	arrows := []rune{'─', '╲', '│', '╱', '─', '╲', '│', '╱'}
+	idx := int(math.Floor((angle+math.Pi)/(math.Pi/4)+0.5)) % len(arrows)
+	if idx < 0 {
+		idx += len(arrows)
+	}
+	if intensity < 0.35 {
+		return ' ', theme.colorAt(0.1), theme.background
+	}
+	return arrows[idx], theme.colorAt(intensity), theme.background
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+)
+
+// simulation is a background that owns grid state and advances it every
+// frame, unlike shaderFunc which is a pure function of position and time.
+type simulation interface {
+	resize(width, height int, rng *rand.Rand)
+	step(dt float64, rng *rand.Rand)
+	// disturb injects energy at a cell; followers brush lightly (~0.3) while
+	// seeds hit hard (1.0).
+	disturb(x, y int, energy float64, rng *rand.Rand)
+	render(x, y int, theme moodTheme) (rune, string, string)
+}
+
+type simulationEntry struct {
+	name string
+	make func() simulation
+}
+
+var simulations = []simulationEntry{
+	{"gray-scott", func() simulation { return &grayScott{} }},
+	{"life", func() simulation { return &lifeGrid{} }},
+	{"falling sand", func() simulation { return &sandGrid{} }},
+}
+
+// cycleSimulation steps off → each simulation → off.
+func (m *model) cycleSimulation() {
+	m.simIndex++
+	if m.simIndex >= len(simulations) {
+		m.simIndex = -1
+		m.sim = nil
+		return
+	}
+	m.sim = simulations[m.simIndex].make()
+	m.sim.resize(m.canvasWidth, m.canvasHeight, m.rng)
+}
+
+func (m *model) stepSimulation() {
+	if m.sim == nil {
+		return
+	}
+	m.sim.resize(m.canvasWidth, m.canvasHeight, m.rng)
+	for _, f := range m.followers {
+		m.sim.disturb(int(math.Round(f.pos.x)), int(math.Round(f.pos.y)), 0.3, m.rng)
+	}
+	for _, s := range m.seeds {
+		m.sim.disturb(int(math.Round(s.pos.x)), int(math.Round(s.pos.y)), 1, m.rng)
+	}
+	m.sim.step(deltaTime, m.rng)
+}
+
+type grid struct {
+	width, height int
+}
+
+func (g grid) inside(x, y int) bool {
+	return x >= 0 && y >= 0 && x < g.width && y < g.height
+}
+
+func (g grid) index(x, y int) int {
+	return y*g.width + x
+}
+
+// wrapped indexes with toroidal wrap-around.
+func (g grid) wrapped(x, y int) int {
+	return g.index(wrapIndex(x, g.width), wrapIndex(y, g.height))
+}
+
+// grayScott is the Gray-Scott reaction-diffusion model: chemical V feeds on U
+// and the feed/kill rates pick the coral-like regime.
+type grayScott struct {
+	grid
+	u, v   []float64
+	nu, nv []float64
+}
+
+const (
+	gsFeed       = 0.0367
+	gsKill       = 0.0649
+	gsDiffuseU   = 1.0
+	gsDiffuseV   = 0.5
+	gsIterations = 6
+)
+
+func (g *grayScott) resize(width, height int, rng *rand.Rand) {
+	if width == g.width && height == g.height {
+		return
+	}
+	g.grid = grid{width, height}
+	n := width * height
+	g.u, g.v = make([]float64, n), make([]float64, n)
+	g.nu, g.nv = make([]float64, n), make([]float64, n)
+	for i := range g.u {
+		g.u[i] = 1
+	}
+	for i := 0; i < 8 && n > 0; i++ {
+		g.splash(rng.Intn(max(width, 1)), rng.Intn(max(height, 1)), 2)
+	}
+}
+
+func (g *grayScott) splash(cx, cy, r int) {
+	for y := cy - r; y <= cy+r; y++ {
+		for x := cx - r; x <= cx+r; x++ {
+			if g.inside(x, y) {
+				i := g.index(x, y)
+				g.u[i] = 0.5
+				g.v[i] = 0.25
+			}
+		}
+	}
+}
+
+func (g *grayScott) disturb(x, y int, energy float64, rng *rand.Rand) {
+	if !g.inside(x, y) {
+		return
+	}
+	if energy >= 1 {
+		g.splash(x, y, 1)
+		return
+	}
+	i := g.index(x, y)
+	g.v[i] = math.Min(1, g.v[i]+energy*0.5)
+}
+
+func (g *grayScott) step(dt float64, rng *rand.Rand) {
+	if g.width == 0 || g.height == 0 {
+		return
+	}
+	for iter := 0; iter < gsIterations; iter++ {
+		for y := 0; y < g.height; y++ {
+			for x := 0; x < g.width; x++ {
+				i := g.index(x, y)
+				lapU := -g.u[i] + 0.2*(g.u[g.wrapped(x-1, y)]+g.u[g.wrapped(x+1, y)]+g.u[g.wrapped(x, y-1)]+g.u[g.wrapped(x, y+1)]) +
+					0.05*(g.u[g.wrapped(x-1, y-1)]+g.u[g.wrapped(x+1, y-1)]+g.u[g.wrapped(x-1, y+1)]+g.u[g.wrapped(x+1, y+1)])
+				lapV := -g.v[i] + 0.2*(g.v[g.wrapped(x-1, y)]+g.v[g.wrapped(x+1, y)]+g.v[g.wrapped(x, y-1)]+g.v[g.wrapped(x, y+1)]) +
+					0.05*(g.v[g.wrapped(x-1, y-1)]+g.v[g.wrapped(x+1, y-1)]+g.v[g.wrapped(x-1, y+1)]+g.v[g.wrapped(x+1, y+1)])
+				uvv := g.u[i] * g.v[i] * g.v[i]
+				g.nu[i] = clamp(g.u[i]+gsDiffuseU*lapU-uvv+gsFeed*(1-g.u[i]), 0, 1)
+				g.nv[i] = clamp(g.v[i]+gsDiffuseV*lapV+uvv-(gsKill+gsFeed)*g.v[i], 0, 1)
+			}
+		}
+		g.u, g.nu = g.nu, g.u
+		g.v, g.nv = g.nv, g.v
+	}
+}
+
+func (g *grayScott) render(x, y int, theme moodTheme) (rune, string, string) {
+	if !g.inside(x, y) {
+		return ' ', "", theme.background
+	}
+	v := clamp(g.v[g.index(x, y)]*3, 0, 1)
+	if v < 0.08 {
+		return ' ', theme.colorAt(0.1), theme.background
+	}
+	return wispGlyph(theme, v), theme.colorAt(0.35 + v*0.65), blendHex(theme.background, theme.colorAt(v), v*0.5)
+}
+
+// lifeGrid is Conway's Game of Life on a torus. Cells remember how long they
+// have lived so old colonies cool through the palette, and recently dead cells
+// leave a fading ember.
+type lifeGrid struct {
+	grid
+	age    []int
+	next   []int
+	ember  []float64
+	timer  float64
+	period float64
+}
+
+func (l *lifeGrid) resize(width, height int, rng *rand.Rand) {
+	if width == l.width && height == l.height {
+		return
+	}
+	l.grid = grid{width, height}
+	n := width * height
+	l.age = make([]int, n)
+	l.next = make([]int, n)
+	l.ember = make([]float64, n)
+	l.period = 0.12
+	l.seed(rng, 0.22)
+}
+
+func (l *lifeGrid) seed(rng *rand.Rand, density float64) {
+	for i := range l.age {
+		if rng.Float64() < density {
+			l.age[i] = 1
+		}
+	}
+}
+
+func (l *lifeGrid) disturb(x, y int, energy float64, rng *rand.Rand) {
+	if !l.inside(x, y) {
+		return
+	}
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			if rng.Float64() < energy*0.35 {
+				i := l.wrapped(x+dx, y+dy)
+				if l.age[i] == 0 {
+					l.age[i] = 1
+				}
+			}
+		}
+	}
+}
+
+func (l *lifeGrid) step(dt float64, rng *rand.Rand) {
+	for i := range l.ember {
+		l.ember[i] *= 0.9
+	}
+	l.timer += dt
+	if l.timer < l.period {
+		return
+	}
+	l.timer -= l.period
+	alive := 0
+	for y := 0; y < l.height; y++ {
+		for x := 0; x < l.width; x++ {
+			neighbours := 0
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					if (dx != 0 || dy != 0) && l.age[l.wrapped(x+dx, y+dy)] > 0 {
+						neighbours++
+					}
+				}
+			}
+			i := l.index(x, y)
+			switch {
+			case l.age[i] > 0 && (neighbours == 2 || neighbours == 3):
+				l.next[i] = l.age[i] + 1
+			case l.age[i] == 0 && neighbours == 3:
+				l.next[i] = 1
+			default:
+				if l.age[i] > 0 {
+					l.ember[i] = 1
+				}
+				l.next[i] = 0
+			}
+			if l.next[i] > 0 {
+				alive++
+			}
+		}
+	}
+	l.age, l.next = l.next, l.age
+	if alive < len(l.age)/50 {
+		l.seed(rng, 0.08)
+	}
+}
+
+func (l *lifeGrid) render(x, y int, theme moodTheme) (rune, string, string) {
+	if !l.inside(x, y) {
+		return ' ', "", theme.background
+	}
+	i := l.index(x, y)
+	if age := l.age[i]; age > 0 {
+		heat := 1 - math.Min(float64(age)/40, 0.8)
+		return '●', theme.colorAt(heat), theme.background
+	}
+	if e := l.ember[i]; e > 0.1 {
+		return '·', theme.colorAt(e * 0.5), theme.background
+	}
+	return ' ', theme.colorAt(0.1), theme.background
+}
+
+// sandGrid is a falling-sand automaton. Followers sprinkle grains tinted by
+// their horizontal position and seeds blast craters in the pile.
+type sandGrid struct {
+	grid
+	grains []float64 // palette position + 1; zero is empty
+}
+
+func (s *sandGrid) resize(width, height int, rng *rand.Rand) {
+	if width == s.width && height == s.height {
+		return
+	}
+	s.grid = grid{width, height}
+	s.grains = make([]float64, width*height)
+}
+
+func (s *sandGrid) disturb(x, y int, energy float64, rng *rand.Rand) {
+	if !s.inside(x, y) {
+		return
+	}
+	if energy >= 1 {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if s.inside(x+dx, y+dy) {
+					s.grains[s.index(x+dx, y+dy)] = 0
+				}
+			}
+		}
+		return
+	}
+	i := s.index(x, y)
+	if s.grains[i] == 0 && rng.Float64() < energy {
+		s.grains[i] = 1 + float64(x)/float64(max(s.width, 1))
+	}
+}
+
+func (s *sandGrid) step(dt float64, rng *rand.Rand) {
+	filled := 0
+	for y := s.height - 2; y >= 0; y-- {
+		// Alternate sweep direction so piles do not lean one way.
+		for k := 0; k < s.width; k++ {
+			x := k
+			if y%2 == 1 {
+				x = s.width - 1 - k
+			}
+			i := s.index(x, y)
+			if s.grains[i] == 0 {
+				continue
+			}
+			below := s.index(x, y+1)
+			if s.grains[below] == 0 {
+				s.grains[below], s.grains[i] = s.grains[i], 0
+				continue
+			}
+			dir := 1
+			if rng.Intn(2) == 0 {
+				dir = -1
+			}
+			for _, dx := range []int{dir, -dir} {
+				if s.inside(x+dx, y+1) && s.grains[s.index(x+dx, y+1)] == 0 {
+					j := s.index(x+dx, y+1)
+					s.grains[j], s.grains[i] = s.grains[i], 0
+					break
+				}
+			}
+		}
+	}
+	for _, g := range s.grains {
+		if g > 0 {
+			filled++
+		}
+	}
+	// Drain the floor once the pile gets too tall so the stage never clogs.
+	if s.height > 0 && filled > len(s.grains)/3 {
+		for x := 0; x < s.width; x++ {
+			s.grains[s.index(x, s.height-1)] = 0
+		}
+	}
+}
+
+func (s *sandGrid) render(x, y int, theme moodTheme) (rune, string, string) {
+	if !s.inside(x, y) {
+		return ' ', "", theme.background
+	}
+	if g := s.grains[s.index(x, y)]; g > 0 {
+		return '▓', theme.colorAt(g - 1), blendHex(theme.background, theme.colorAt(g-1), 0.3)
+	}
+	return ' ', theme.colorAt(0.1), theme.background
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/audio"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/midi"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/tempo"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// splitCounts are the layouts the split key walks through: one garden, two
+// side by side, or a 2x2 grid.
+var splitCounts = []int{1, 2, 4}
+
+const (
+	paneLabelFg   = "#FFFFD7"
+	paneLabelBg   = "#3A3A3A"
+	paneFocusBg   = "#5F00FF"
+	paneSeparator = "#FF87D7"
+	paneGutter    = "#000000"
+)
+
+// cycleSplit moves to the next layout. Existing panes keep their tuning;
+// new ones start as a copy of the garden being split, and going back to one
+// garden keeps the focused pane's.
+func (m *model) cycleSplit() {
+	m.splitIndex = (m.splitIndex + 1) % len(splitCounts)
+	n := splitCounts[m.splitIndex]
+	if n == 1 {
+		if len(m.panes) > 0 {
+			m.adoptPane(m.focused())
+		}
+		m.panes = nil
+		m.focus = 0
+		return
+	}
+	for len(m.panes) < n {
+		m.panes = append(m.panes, m.spawnPane())
+	}
+	m.panes = m.panes[:n]
+	m.focus = min(m.focus, n-1)
+	m.layoutPanes()
+}
+
+// spawnPane builds a sub-garden with the focused garden's parameters. It
+// shares the rng and style table with the parent but owns its springs, seeds
+// and background. The analyser is only kept so the pane knows audio is on;
+// the parent reads it and passes each frame down.
+func (m *model) spawnPane() *model {
+	src := m.focused()
+	p := newModel()
+	p.rng = m.rng
+	p.styleCache = m.styleCache
+	p.export = m.export
+	p.audio = m.audio
+	p.midiFollowers = m.midiFollowers
+	p.freq = src.freq
+	p.damping = src.damping
+	p.formation = src.formation
+	p.moodIndex = src.moodIndex
+	p.sceneIndex = m.sceneIndex
+	p.shaderIndex = src.shaderIndex
+	if src.simIndex >= 0 {
+		p.simIndex = src.simIndex
+		p.sim = simulations[src.simIndex].make()
+	}
+	p.spreadIndex = src.spreadIndex
+	p.constellation = src.constellation
+	p.mods.lfos = append([]lfo(nil), src.mods.lfos...)
+	p.clock = m.clock
+	p.t = m.t
+	p.shaderT = src.shaderT
+	p.autop = false
+	p.pendingMuses = len(src.followers)
+	if !src.ready {
+		p.pendingMuses = src.pendingMuses
+	}
+	return &p
+}
+
+// adoptPane copies a pane's tuning back into the parent, the reverse of
+// spawnPane. The parent keeps its own springs and seeds, so only their count,
+// personalities and tuning follow the pane.
+func (m *model) adoptPane(p *model) {
+	m.freq = p.freq
+	m.damping = p.damping
+	m.formation = p.formation
+	m.moodIndex = p.moodIndex
+	m.shaderIndex = p.shaderIndex
+	m.shaderT = p.shaderT
+	if m.simIndex != p.simIndex {
+		m.simIndex = p.simIndex
+		m.sim = nil
+		if p.simIndex >= 0 {
+			m.sim = simulations[p.simIndex].make()
+			m.sim.resize(m.canvasWidth, m.canvasHeight, m.rng)
+		}
+	}
+	m.constellation = p.constellation
+	m.mods.lfos = append([]lfo(nil), p.mods.lfos...)
+	m.mods.selected = min(m.mods.selected, max(len(m.mods.lfos)-1, 0))
+	m.setParam(editMuses, float64(len(p.followers)))
+	if m.spreadIndex != p.spreadIndex {
+		m.spreadIndex = p.spreadIndex
+		for _, f := range m.followers {
+			f.scatter(personalitySpreads[m.spreadIndex], m.rng)
+		}
+	}
+	m.retuneFollowers()
+}
+
+// layoutPanes carves the parent canvas into pane canvases, leaving a
+// separator column between neighbours and a label row above each pane.
+func (m *model) layoutPanes() {
+	n := len(m.panes)
+	if n == 0 || m.canvasWidth == 0 {
+		return
+	}
+	cols := 2
+	rows := n / cols
+	w := max((m.canvasWidth-(cols-1))/cols, 8)
+	h := max((m.canvasHeight-rows)/rows, 3)
+	for _, p := range m.panes {
+		p.width, p.height = w, h
+		p.canvasWidth, p.canvasHeight = w, h
+		if !p.ready {
+			p.target = m.paneTarget(p)
+			for len(p.followers) < p.pendingMuses {
+				p.addFollower()
+			}
+			p.ready = true
+		}
+		p.clampTarget()
+		if p.sim != nil {
+			p.sim.resize(w, h, p.rng)
+		}
+	}
+}
+
+// paneTarget maps the shared focal point into a pane's canvas.
+func (m *model) paneTarget(p *model) vector {
+	if m.canvasWidth == 0 || m.canvasHeight == 0 {
+		return vector{}
+	}
+	return vector{
+		m.target.x / float64(m.canvasWidth) * float64(p.canvasWidth),
+		m.target.y / float64(m.canvasHeight) * float64(p.canvasHeight),
+	}
+}
+
+// stepPanes advances every pane along the shared target path. Each pane runs
+// from the clock as it stood before this frame so beat crossings line up
+// with the parent's. Every pane hears the audio and plays the notes, but only
+// the focused one follows the MIDI controllers, like any other parameter.
+func (m *model) stepPanes(clock tempo.Clock, frame audio.Frame, events []midi.Event) {
+	for i, p := range m.panes {
+		p.clock = clock
+		p.target = m.paneTarget(p)
+		p.t += deltaTime
+		p.clock.Advance(deltaTime)
+		p.step(frame, events, i == m.focus)
+	}
+}
+
+// focused returns the garden that parameter keys act on.
+func (m *model) focused() *model {
+	if len(m.panes) == 0 {
+		return m
+	}
+	return m.panes[m.focus]
+}
+
+func (m *model) isGlobalKey(msg tea.KeyMsg) bool {
+	k := m.keys
+	return key.Matches(msg, k.Quit, k.ToggleMode, k.CycleScene, k.MoveNorth, k.MoveSouth, k.MoveWest, k.MoveEast,
+		k.TapTempo, k.TempoUp, k.TempoDown, k.ToggleSync, k.ToggleHelp, k.Split, k.FocusPane)
+}
+
+// updatePaneKey forwards garden keys to the focused pane while the screen is
+// split. Open panels in the pane see every key except quit and the split
+// controls, which always belong to the parent.
+func (m *model) updatePaneKey(msg tea.KeyMsg) bool {
+	if len(m.panes) == 0 || key.Matches(msg, m.keys.Quit, m.keys.Split, m.keys.FocusPane) {
+		return false
+	}
+	p := m.panes[m.focus]
+	if p.panel == panelNone && m.isGlobalKey(msg) {
+		return false
+	}
+	next, _ := p.updateKey(msg)
+	*p = next.(model)
+	return true
+}
+
+// paintPanes lays the panes out in a grid on one parent-sized canvas, each
+// under a one-line label summarising its tuning. The focused pane's label is
+// highlighted.
+func (m *model) paintPanes() [][]cell {
+	canvas := make([][]cell, m.canvasHeight)
+	for y := range canvas {
+		canvas[y] = make([]cell, m.canvasWidth)
+		for x := range canvas[y] {
+			canvas[y][x] = cell{ch: ' ', bg: paneGutter}
+		}
+	}
+	cols := 2
+	for i, p := range m.panes {
+		left := (i % cols) * (p.canvasWidth + 1)
+		top := (i / cols) * (p.canvasHeight + 1)
+		labelBg := paneLabelBg
+		if i == m.focus {
+			labelBg = paneFocusBg
+		}
+		label := []rune(fmt.Sprintf(" %d %s • %s • f %.2f d %.2f • %d", i+1, p.currentMood().name, formations[indexOfFormation(p.formation)].name, p.freq, p.damping, len(p.followers)))
+		for x := 0; x < p.canvasWidth; x++ {
+			ch := ' '
+			if x < len(label) {
+				ch = label[x]
+			}
+			setCell(canvas, left+x, top, cell{ch: ch, fg: paneLabelFg, bg: labelBg, bold: i == m.focus})
+		}
+		for y, row := range p.paintStage() {
+			for x, c := range row {
+				setCell(canvas, left+x, top+1+y, c)
+			}
+		}
+		if i%cols > 0 {
+			for y := top; y <= top+p.canvasHeight; y++ {
+				setCell(canvas, left-1, y, cell{ch: '│', fg: paneSeparator, bg: paneGutter})
+			}
+		}
+	}
+	return canvas
+}
+
+func setCell(canvas [][]cell, x, y int, c cell) {
+	if y >= 0 && y < len(canvas) && x >= 0 && x < len(canvas[y]) {
+		canvas[y][x] = c
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+// TestUnsplitKeepsFocusedPane tunes the second pane of a split, goes back to
+// one garden and checks the parent took that pane's tuning, then that u puts
+// the old tuning back.
+func TestUnsplitKeepsFocusedPane(t *testing.T) {
+	m := press(readyModel(t), "w")
+	if len(m.panes) != 2 {
+		t.Fatalf("split made %d panes, want 2", len(m.panes))
+	}
+	before := m.params()
+	m = press(m, "n")
+	for _, k := range []string{"m", "a", "a"} {
+		m = press(m, k)
+	}
+	m.focused().cycleSimulation()
+	pane := *m.focused()
+
+	m = press(press(m, "w"), "w")
+	if len(m.panes) != 0 {
+		t.Fatalf("still %d panes after cycling back", len(m.panes))
+	}
+	if got, want := m.params(), pane.params(); got != want {
+		t.Errorf("parent params %v, want the focused pane's %v", got, want)
+	}
+	if m.simIndex != pane.simIndex || m.sim == nil {
+		t.Errorf("parent simulation %d, want %d", m.simIndex, pane.simIndex)
+	}
+
+	m = press(m, "u")
+	if got := m.params(); got != before {
+		t.Errorf("after undo params %v, want %v", got, before)
+	}
+}
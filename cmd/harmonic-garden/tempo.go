@@ -0,0 +1,20 @@
+package main
+
+import "github.com/ThomasVuNguyen/charm-experiments/internal/tempo"
+
+// sceneTime is the clock scene functions are evaluated on. When beat lock is
+// on it is derived from the beat counter, scaled so that the default tempo
+// matches free-running speed; scenes then speed up and slow down with the BPM.
+func (m *model) sceneTime() float64 {
+	if !m.clock.Locked {
+		return m.t
+	}
+	return m.clock.Beats() * 60 / tempo.DefaultBPM
+}
+
+func (m *model) beatIndicator() string {
+	if !m.clock.Locked {
+		return m.clock.Indicator() + " free"
+	}
+	return m.clock.Indicator() + " lock"
+}
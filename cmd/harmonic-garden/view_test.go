@@ -0,0 +1,27 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// BenchmarkView200x60 renders a 200x60 garden once per op, stepping it
+// between frames so colours move the way they do on screen.
+func BenchmarkView200x60(b *testing.B) {
+	next, _ := newModel().Update(tea.WindowSizeMsg{Width: 200, Height: 60 + statusLines})
+	m := next.(model)
+	if m.canvasWidth != 200 || m.canvasHeight != 60 {
+		b.Fatalf("canvas is %dx%d", m.canvasWidth, m.canvasHeight)
+	}
+	for i := 0; i < fps; i++ {
+		m.advance()
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		m.advance()
+		_ = m.View()
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"time"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+)
+
+// creatureSprites names the sprite file each page draws. A file of the same
+// name in the user sprite directory replaces the built-in one.
+var creatureSprites = [totalPages]string{
+	pageJellyfishHorse:     "jellyfish-horse",
+	pageCactusOctopus:      "cactus-octopus",
+	pageClockworkButterfly: "clockwork-butterfly",
+	pageGlowmushroomSloth:  "glowmushroom-sloth",
+	pageCrystalSpider:      "crystal-spider",
+	pageNoodleWhale:        "noodle-whale",
+	pageEyestalkTurtle:     "eyestalk-turtle",
+	pageFeatherFish:        "feather-fish",
+	pageGeometricBee:       "geometric-bee",
+	pageVoidSquid:          "void-squid",
+	pagePrismaticWorm:      "prismatic-worm",
+	pageTentacleTree:       "tentacle-tree",
+	pageFloatingBrain:      "floating-brain",
+}
+
+func loadCreatures(lib sprite.Library) ([totalPages]*sprite.Sprite, error) {
+	var creatures [totalPages]*sprite.Sprite
+	for p, name := range creatureSprites {
+		s, err := lib.Get(name)
+		if err != nil {
+			return creatures, err
+		}
+		creatures[p] = s
+	}
+	return creatures, nil
+}
+
+// animRate is how many animation ticks pass per frame. Loud mid-range
+// passages push creatures a little faster when audio is playing.
+func (m model) animRate() float64 {
+	rate := menagerie.Moods[m.scene.Mood].AnimSpeed
+	if m.audio != nil {
+		rate *= 1 + 0.5*m.react.Mid
+	}
+	return rate
+}
+
+// creatureFrame picks the current page's frame. Frame durations in the
+// sprite files are for a mood speed of 1, one tick per tick interval.
+func (m model) creatureFrame() *sprite.Frame {
+	elapsed := time.Duration(m.animTicks * float64(tickInterval))
+	return m.creatures[m.currentPage].FrameAt(elapsed)
+}
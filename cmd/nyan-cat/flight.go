@@ -0,0 +1,234 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type flightPath int
+
+const (
+	hoverPath flightPath = iota
+	scrollPath
+	figureEightPath
+	mousePath
+	pilotPath
+	flightPathCount
+)
+
+var flightPathNames = [flightPathCount]string{"hover", "scroll", "figure-eight", "mouse", "pilot"}
+
+func (f flightPath) String() string { return flightPathNames[f] }
+
+func parseFlightPath(name string) (flightPath, error) {
+	for i, n := range flightPathNames {
+		if n == name {
+			return flightPath(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown flight path %q (want hover, scroll, figure-eight, mouse or pilot)", name)
+}
+
+type trailStyle int
+
+const (
+	rainbowTrail trailStyle = iota
+	glyphTrail
+	noTrail
+	trailStyleCount
+)
+
+var trailStyleNames = [trailStyleCount]string{"rainbow", "glyphs", "off"}
+
+func (t trailStyle) String() string { return trailStyleNames[t] }
+
+func parseTrailStyle(name string) (trailStyle, error) {
+	for i, n := range trailStyleNames {
+		if n == name {
+			return trailStyle(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown trail %q (want rainbow, glyphs or off)", name)
+}
+
+const (
+	scrollSpeed  = 1.0  // cells a tick
+	mouseEase    = 0.15 // share of the gap to the pointer closed each tick
+	pilotThrust  = 1.2
+	pilotDamping = 0.85
+	trailLength  = 48 // ticks a trail point lives
+	trailDrift   = 1.0
+)
+
+// rainbow is the classic six-band trail, top to bottom.
+var rainbow = []lipgloss.Color{"196", "208", "226", "46", "33", "129"}
+
+// trailPoint is where the creature was on one tick.
+type trailPoint struct {
+	x, y float64
+}
+
+// fly moves the creature along its path once a tick. Every path works in
+// floating point so slow moves still glide, and the grid position is rounded
+// from it.
+func (m *model) fly() {
+	w, h := float64(m.width), float64(m.height)
+	t := m.scene.Time
+	bob := 4*math.Sin(t*1.5) + 2*math.Cos(t*2.3)
+
+	switch m.path {
+	case scrollPath:
+		m.posX += scrollSpeed
+		span := 2 * float64(m.creatureSpan())
+		if m.posX > w+span {
+			m.posX = -span
+		}
+		m.posY = h/2 + bob
+	case figureEightPath:
+		m.posX = w/2 + w/3*math.Sin(t*0.9)
+		m.posY = h/2 + h/3*math.Sin(t*1.8)
+	case mousePath:
+		m.posX += (float64(m.mouseX) - m.posX) * mouseEase
+		m.posY += (float64(m.mouseY) - m.posY) * mouseEase
+	case pilotPath:
+		m.posX = math.Max(0, math.Min(w-1, m.posX+m.velX))
+		m.posY = math.Max(0, math.Min(h-1, m.posY+m.velY))
+		m.velX *= pilotDamping
+		m.velY *= pilotDamping
+	default:
+		// Move creature in complex harmonic patterns
+		m.posX = w/2 + 2*math.Sin(t*0.8)
+		m.posY = h/2 + bob
+	}
+	m.creatureX = int(math.Round(m.posX))
+	m.creatureY = int(math.Round(m.posY))
+}
+
+// creatureSpan is the current creature's width in sprite pixels.
+func (m model) creatureSpan() int {
+	if s := m.creatures[m.currentPage]; s != nil {
+		return s.Width
+	}
+	return 0
+}
+
+// steer handles the pilot's arrow keys. It reports whether key was one.
+func (m *model) steer(key string) bool {
+	switch key {
+	case "up", "k":
+		m.velY -= pilotThrust / 2
+	case "down", "j":
+		m.velY += pilotThrust / 2
+	case "left", "h":
+		m.velX -= pilotThrust
+	case "right", "l":
+		m.velX += pilotThrust
+	default:
+		return false
+	}
+	return true
+}
+
+// setPath switches flight path, capturing the mouse only while it steers.
+func (m *model) setPath(p flightPath) tea.Cmd {
+	was := m.path
+	m.path = p
+	m.velX, m.velY = 0, 0
+	m.mouseX, m.mouseY = m.creatureX, m.creatureY
+	switch {
+	case p == mousePath:
+		return tea.EnableMouseAllMotion
+	case was == mousePath:
+		return tea.DisableMouse
+	}
+	return nil
+}
+
+// emitTrail drops a trail point where the creature is and lets older points
+// drift back, so even a hovering creature streams a tail. While scrolling the
+// creature's own motion lays the trail out instead.
+func (m *model) emitTrail() {
+	drift := trailDrift
+	if m.path == scrollPath {
+		drift = 0
+	}
+	for i := range m.trail {
+		m.trail[i].x -= drift
+	}
+	m.trail = append(m.trail, trailPoint{m.posX, m.posY})
+	if len(m.trail) > trailLength {
+		m.trail = m.trail[len(m.trail)-trailLength:]
+	}
+}
+
+// drawTrail joins the trail points oldest first so the newest lies on top.
+// Points far apart are a wrap or a jump, and are not joined.
+func (m model) drawTrail(grid [][]menagerie.Pixel) {
+	if m.trailStyle == noTrail || len(m.trail) == 0 {
+		return
+	}
+	mood := menagerie.Moods[m.scene.Mood]
+	for i := range m.trail {
+		age := len(m.trail) - 1 - i
+		a, b := m.trail[i], m.trail[i]
+		if i+1 < len(m.trail) {
+			b = m.trail[i+1]
+		}
+		if math.Abs(b.x-a.x) > float64(m.width)/2 {
+			b = a
+		}
+		steps := int(math.Max(math.Abs(b.x-a.x), math.Abs(b.y-a.y))) + 1
+		for s := 0; s < steps; s++ {
+			f := float64(s) / float64(steps)
+			x := int(math.Round(a.x + (b.x-a.x)*f))
+			y := int(math.Round(a.y + (b.y-a.y)*f))
+			if m.trailStyle == glyphTrail {
+				m.drawGlyphSegment(grid, x, y, age, mood)
+			} else {
+				m.drawRainbowSegment(grid, x, y, age)
+			}
+		}
+	}
+}
+
+// drawRainbowSegment paints one column of bands. The bands step up and down
+// every few ticks like the original cat's and thin out toward the tail.
+func (m model) drawRainbowSegment(grid [][]menagerie.Pixel, x, y, age int) {
+	char := "█"
+	switch {
+	case age > trailLength*5/6:
+		char = "░"
+	case age > trailLength*2/3:
+		char = "▒"
+	case age > trailLength/2:
+		char = "▓"
+	}
+	top := y - len(rainbow)/2 + (age/4)%2
+	for band, color := range rainbow {
+		putPixel(grid, x, top+band, menagerie.Pixel{Char: char, Color: color})
+	}
+}
+
+// drawGlyphSegment scatters the mood's glyphs along the trail, dimming
+// through the palette as they age.
+func (m model) drawGlyphSegment(grid [][]menagerie.Pixel, x, y, age int, mood menagerie.Mood) {
+	// Scrolling wraps the creature off the left edge, so trail points can
+	// lie off screen, where x would also index the glyphs out of range.
+	if x < 0 || x >= m.width || (x+age)%2 == 1 {
+		return
+	}
+	shade := len(mood.Palette) - 1 - age*len(mood.Palette)/(trailLength+1)
+	glyph := mood.Glyphs[(x/2+age)%len(mood.Glyphs)]
+	wave := int(math.Round(2 * math.Sin(float64(x)*0.3+m.scene.Time*4)))
+	putPixel(grid, x, y+wave, menagerie.Pixel{Char: string(glyph), Color: mood.Palette[max(0, shade)]})
+}
+
+func putPixel(grid [][]menagerie.Pixel, x, y int, p menagerie.Pixel) {
+	if y >= 0 && y < len(grid) && x >= 0 && x < len(grid[y]) {
+		grid[y][x] = p
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+)
+
+// TestGlyphTrailAcrossScrollWrap flies the scroll path off the right edge and
+// back in from the left, drawing the glyph trail every tick.
+func TestGlyphTrailAcrossScrollWrap(t *testing.T) {
+	lib, err := sprite.Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := newModel()
+	if m.creatures, err = loadCreatures(lib); err != nil {
+		t.Fatal(err)
+	}
+	m.setPath(scrollPath)
+	m.trailStyle = glyphTrail
+	m.posX = float64(m.width)
+
+	wrapped := false
+	for i := 0; i < 3*m.creatureSpan(); i++ {
+		m.fly()
+		m.emitTrail()
+		if m.posX < 0 {
+			wrapped = true
+		}
+		m.drawTrail(m.paintPage())
+	}
+	if !wrapped {
+		t.Fatal("creature never wrapped off the left edge")
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Thumbnails are drawn with half blocks, two pixels to a cell, so a
+// thumbWidth by thumbHeight box takes thumbHeight/2 lines.
+const (
+	thumbWidth  = 14
+	thumbHeight = 10
+	galleryCell = thumbWidth + 2
+	galleryRow  = thumbHeight/2 + 2 // thumbnail, name and a gap
+)
+
+var pageNames = [totalPages]string{"Jellyfish Horse", "Cactus Octopus", "Clockwork Butterfly", "Glowmushroom Sloth", "Crystal Spider", "Noodle Whale", "Eyestalk Turtle", "Feather Fish", "Geometric Bee", "Void Squid", "Prismatic Worm", "Tentacle Tree", "Floating Brain"}
+
+func (m model) galleryColumns() int {
+	return max(1, min(int(totalPages), m.width/galleryCell))
+}
+
+func (m model) updateGallery(msg tea.KeyMsg) model {
+	cols := page(m.galleryColumns())
+	switch msg.String() {
+	case "esc", "g", "q":
+		m.gallery = false
+	case "enter", " ":
+		m.currentPage = m.gallerySel
+		m.gallery = false
+	case "left", "h":
+		m.gallerySel = (m.gallerySel - 1 + totalPages) % totalPages
+	case "right", "l", "tab":
+		m.gallerySel = (m.gallerySel + 1) % totalPages
+	case "up", "k":
+		if m.gallerySel >= cols {
+			m.gallerySel -= cols
+		}
+	case "down", "j":
+		if m.gallerySel+cols < totalPages {
+			m.gallerySel += cols
+		}
+	case "/":
+		m.gallery = false
+		m.startSearch()
+	}
+	return m
+}
+
+// galleryView lays every creature out as an animated thumbnail, scrolled so
+// the selection stays on screen in short terminals.
+func (m model) galleryView() string {
+	mood := menagerie.Moods[m.scene.Mood]
+	cols := m.galleryColumns()
+	rows := (int(totalPages) + cols - 1) / cols
+	visible := max(1, (m.height-3)/galleryRow)
+	first := max(0, min(int(m.gallerySel)/cols-visible+1, rows-visible))
+
+	elapsed := time.Duration(m.animTicks * float64(tickInterval))
+	var lines []string
+	for r := first; r < min(rows, first+visible); r++ {
+		var cells []string
+		for c := 0; c < cols; c++ {
+			p := page(r*cols + c)
+			if p >= totalPages {
+				break
+			}
+			name := pageNames[p]
+			if len(name) > thumbWidth {
+				name = name[:thumbWidth-1] + "…"
+			}
+			label := lipgloss.NewStyle().Width(thumbWidth).Align(lipgloss.Center).Foreground(lipgloss.Color("244"))
+			if p == m.gallerySel {
+				label = label.Foreground(mood.Accent).Bold(true).Reverse(true)
+			}
+			thumb := thumbnail(m.creatures[p], elapsed)
+			cell := lipgloss.JoinVertical(lipgloss.Left, thumb, label.Render(name))
+			cells = append(cells, lipgloss.NewStyle().Width(galleryCell).Render(cell))
+		}
+		lines = append(lines, lipgloss.JoinHorizontal(lipgloss.Top, cells...), "")
+	}
+
+	title := lipgloss.NewStyle().Foreground(mood.Accent).Bold(true).Render("Menagerie")
+	controls := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).
+		Render("arrows or hjkl to choose • enter to visit • / to search • esc to close")
+	return lipgloss.JoinVertical(lipgloss.Left, title, strings.Join(lines, "\n"), controls)
+}
+
+// thumbnail shrinks s's current frame to fit the thumbnail box by nearest
+// neighbour sampling. A pixel is one cell wide and half a cell tall, which
+// keeps the proportions nyan-cat's double-width pixels give.
+func thumbnail(s *sprite.Sprite, elapsed time.Duration) string {
+	blank := strings.Repeat(" ", thumbWidth)
+	lines := make([]string, thumbHeight/2)
+	for i := range lines {
+		lines[i] = blank
+	}
+	if s == nil || s.Width == 0 || s.Height == 0 {
+		return strings.Join(lines, "\n")
+	}
+
+	rows := s.FrameAt(elapsed).Rows
+	scale := min(1, float64(thumbWidth)/float64(s.Width), float64(thumbHeight)/float64(s.Height))
+	w := max(1, int(float64(s.Width)*scale))
+	h := max(1, int(float64(s.Height)*scale))
+	padX, padY := (thumbWidth-w)/2, (thumbHeight-h)/2
+	at := func(x, y int) (string, bool) {
+		x, y = x-padX, y-padY
+		if x < 0 || x >= w || y < 0 || y >= h {
+			return "", false
+		}
+		row := []rune(rows[min(int(float64(y)/scale), len(rows)-1)])
+		sx := int(float64(x) / scale)
+		if sx >= len(row) {
+			return "", false
+		}
+		return s.Color(row[sx])
+	}
+
+	for ly := range lines {
+		var b strings.Builder
+		for x := 0; x < thumbWidth; x++ {
+			top, hasTop := at(x, 2*ly)
+			bottom, hasBottom := at(x, 2*ly+1)
+			switch {
+			case hasTop && hasBottom:
+				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(top)).Background(lipgloss.Color(bottom)).Render("▀"))
+			case hasTop:
+				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(top)).Render("▀"))
+			case hasBottom:
+				b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(bottom)).Render("▄"))
+			default:
+				b.WriteString(" ")
+			}
+		}
+		lines[ly] = b.String()
+	}
+	return strings.Join(lines, "\n")
+}
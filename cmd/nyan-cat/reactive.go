@@ -0,0 +1,38 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/audio"
+)
+
+// listen reads the analyzer at the wall-clock playback position. The scene's
+// clock runs slower than real time, so audio keeps its own clock to stay in
+// tempo.
+func (m *model) listen() {
+	if m.audio == nil {
+		return
+	}
+	m.audioClock += tickInterval.Seconds()
+	m.react = m.audio.At(m.audioClock)
+
+	m.scene.Treble = m.react.Treble
+	m.scene.Reactive = true
+
+	m.scene.Swell(m.react.Bass * 2)
+	if m.react.Onset {
+		// Restart the oldest ring at the creature so hits radiate from it.
+		m.scene.Burst(float64(m.creatureX), float64(m.creatureY))
+	}
+	if m.react.Mid > 0.5 && rand.Float64() < m.react.Mid*0.5 {
+		m.scene.Fling(float64(m.creatureX), float64(m.creatureY), 4*m.react.Mid)
+	}
+}
+
+func loadAnalyzer(path string, rate, channels int) (*audio.Analyzer, error) {
+	track, err := audio.Open(path, rate, channels)
+	if err != nil {
+		return nil, err
+	}
+	return audio.NewAnalyzer(track), nil
+}
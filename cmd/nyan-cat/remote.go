@@ -0,0 +1,59 @@
+package main
+
+import (
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/osc"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type oscMsg struct {
+	osc.Message
+}
+
+// serveOSC forwards every incoming OSC message into the program so remote
+// changes are applied on the Update goroutine like any keypress.
+func serveOSC(p *tea.Program, addr string) (*osc.Server, error) {
+	srv, err := osc.Listen(addr)
+	if err != nil {
+		return nil, err
+	}
+	go srv.Serve(func(msg osc.Message) {
+		p.Send(oscMsg{msg})
+	})
+	return srv, nil
+}
+
+// applyOSC handles the /nyan namespace. Pages are numbered from 1 to match
+// the on-screen indicator.
+func (m *model) applyOSC(msg oscMsg) {
+	switch msg.Address {
+	case "/nyan/page":
+		if n, ok := msg.Int(0); ok {
+			m.currentPage = page(wrapIndex(n-1, int(totalPages)))
+		}
+	case "/nyan/next":
+		m.currentPage = (m.currentPage + 1) % totalPages
+	case "/nyan/prev":
+		m.currentPage = (m.currentPage - 1 + totalPages) % totalPages
+	case "/nyan/mood":
+		if n, ok := msg.Int(0); ok {
+			m.scene.Mood = wrapIndex(n, len(menagerie.Moods))
+		}
+	case "/nyan/bpm":
+		if v, ok := msg.Float(0); ok {
+			m.clock.Nudge(v - m.clock.BPM)
+			m.clock.Locked = true
+		}
+	}
+}
+
+func wrapIndex(n, length int) int {
+	if length == 0 {
+		return 0
+	}
+	n %= length
+	if n < 0 {
+		n += length
+	}
+	return n
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// searchResults caps how many matches the prompt lists.
+const searchResults = 5
+
+func (m *model) startSearch() {
+	m.searching = true
+	m.query = ""
+	m.searchSel = 0
+}
+
+func (m model) updateSearch(msg tea.KeyMsg) model {
+	matches := searchPages(m.query)
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.searching = false
+	case tea.KeyEnter:
+		if len(matches) > 0 {
+			m.currentPage = matches[m.searchSel]
+		}
+		m.searching = false
+	case tea.KeyBackspace:
+		if r := []rune(m.query); len(r) > 0 {
+			m.query = string(r[:len(r)-1])
+			m.searchSel = 0
+		}
+	case tea.KeyUp, tea.KeyShiftTab, tea.KeyCtrlP:
+		if len(matches) > 0 {
+			m.searchSel = (m.searchSel - 1 + len(matches)) % len(matches)
+		}
+	case tea.KeyDown, tea.KeyTab, tea.KeyCtrlN:
+		if len(matches) > 0 {
+			m.searchSel = (m.searchSel + 1) % len(matches)
+		}
+	case tea.KeyRunes, tea.KeySpace:
+		m.query += string(msg.Runes)
+		m.searchSel = 0
+	}
+	return m
+}
+
+// searchPages ranks the creatures whose names contain the query's letters in
+// order. An empty query lists every page.
+func searchPages(query string) []page {
+	type hit struct {
+		p     page
+		score int
+	}
+	var hits []hit
+	for p := page(0); p < totalPages; p++ {
+		if score, ok := fuzzyScore(pageNames[p], query); ok {
+			hits = append(hits, hit{p, score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].score > hits[j].score })
+	pages := make([]page, len(hits))
+	for i, h := range hits {
+		pages[i] = h.p
+	}
+	return pages
+}
+
+// fuzzyScore matches query as a case-insensitive subsequence of name, picking
+// the placement that scores best. Letters that start a word and runs of
+// adjacent letters score higher, so "tt" finds Tentacle Tree before
+// Clockwork Butterfly.
+func fuzzyScore(name, query string) (int, bool) {
+	n := []rune(strings.ToLower(name))
+	var q []rune
+	for _, r := range strings.ToLower(query) {
+		if !unicode.IsSpace(r) {
+			q = append(q, r)
+		}
+	}
+
+	// best[i][p] is the top score for q[i:] with q[i-1] matched at p-1.
+	best := make([][]int, len(q)+1)
+	for i := range best {
+		best[i] = make([]int, len(n)+1)
+	}
+	const miss = -1
+	for i := len(q) - 1; i >= 0; i-- {
+		for p := 0; p <= len(n); p++ {
+			best[i][p] = miss
+			for k := p; k < len(n); k++ {
+				if n[k] != q[i] || best[i+1][k+1] == miss {
+					continue
+				}
+				score := 1 + best[i+1][k+1]
+				if k == 0 || n[k-1] == ' ' {
+					score += 6
+				}
+				if i > 0 && k == p {
+					score += 4
+				}
+				best[i][p] = max(best[i][p], score)
+			}
+		}
+	}
+	if best[0][0] == miss {
+		return 0, false
+	}
+	return best[0][0], true
+}
+
+func (m model) searchView() string {
+	mood := menagerie.Moods[m.scene.Mood]
+	prompt := lipgloss.NewStyle().Foreground(mood.Accent).Bold(true).Render("jump to: ") + m.query + "▏"
+
+	matches := searchPages(m.query)
+	first := max(0, m.searchSel-searchResults+1)
+	var shown []string
+	for i := first; i < min(len(matches), first+searchResults); i++ {
+		name := pageNames[matches[i]]
+		if i == m.searchSel {
+			name = lipgloss.NewStyle().Foreground(mood.Palette[0]).Bold(true).Reverse(true).Render(" " + name + " ")
+		} else {
+			name = lipgloss.NewStyle().Foreground(lipgloss.Color("244")).Render(" " + name + " ")
+		}
+		shown = append(shown, name)
+	}
+	results := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("no creature matches")
+	if len(shown) > 0 {
+		results = strings.Join(shown, " ")
+		if len(matches) > searchResults {
+			results += lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(fmt.Sprintf(" +%d", len(matches)-len(shown)))
+		}
+	}
+	controls := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("type to filter • ↑/↓ or tab to choose • enter to jump • esc to cancel")
+	return lipgloss.JoinVertical(lipgloss.Left, prompt, results, controls)
+}
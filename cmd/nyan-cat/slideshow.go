@@ -0,0 +1,208 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type transitionStyle int
+
+const (
+	wipe transitionStyle = iota
+	dissolve
+	pixelSort
+	zoom
+	transitionCount
+	randomTransition = transitionCount
+)
+
+var transitionNames = [...]string{"wipe", "dissolve", "pixel-sort", "zoom", "random"}
+
+func (t transitionStyle) String() string { return transitionNames[t] }
+
+func parseTransition(name string) (transitionStyle, error) {
+	for i, n := range transitionNames {
+		if n == name {
+			return transitionStyle(i), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown transition %q (want wipe, dissolve, pixel-sort, zoom or random)", name)
+}
+
+// slideshow advances through the creatures on a timer for ambient display.
+type slideshow struct {
+	on         bool
+	dwell      int // ticks spent on each creature
+	style      transitionStyle
+	length     int // ticks a transition takes
+	moodEvery  int // ticks between mood changes, or 0 to hold the mood
+	elapsed    int
+	shownPage  page
+	transition transition
+}
+
+// transition is a blend from one page's environment into the current one.
+type transition struct {
+	active bool
+	from   page
+	style  transitionStyle
+	tick   int
+}
+
+func ticksFor(d time.Duration) int {
+	return int(math.Round(float64(d) / float64(tickInterval)))
+}
+
+// stepSlideshow runs once a tick. Changing page by hand restarts the dwell so
+// the next advance never lands right after a jump.
+func (m *model) stepSlideshow() {
+	s := &m.show
+	if s.moodEvery > 0 && m.frame%s.moodEvery == 0 {
+		m.scene.Mood = (m.scene.Mood + 1) % len(menagerie.Moods)
+	}
+	if s.transition.active {
+		s.transition.tick++
+		if s.transition.tick >= s.length {
+			s.transition.active = false
+		}
+	}
+	if m.currentPage != s.shownPage {
+		s.shownPage = m.currentPage
+		s.elapsed = 0
+		s.transition.active = false
+	}
+	if !s.on {
+		return
+	}
+	s.elapsed++
+	if s.elapsed < s.dwell {
+		return
+	}
+	style := s.style
+	if style == randomTransition {
+		style = transitionStyle(rand.Intn(int(transitionCount)))
+	}
+	s.transition = transition{active: s.length > 0, from: m.currentPage, style: style}
+	m.currentPage = (m.currentPage + 1) % totalPages
+	s.shownPage = m.currentPage
+	s.elapsed = 0
+}
+
+func (m model) slideshowIndicator() string {
+	if !m.show.on {
+		return ""
+	}
+	left := time.Duration(m.show.dwell-m.show.elapsed) * tickInterval
+	return fmt.Sprintf(" • ▶ %s next in %ds", m.show.style, int(math.Ceil(left.Seconds())))
+}
+
+// blendTransition mixes the outgoing page's grid into the incoming one.
+func (m model) blendTransition(to [][]menagerie.Pixel) [][]menagerie.Pixel {
+	t := m.show.transition
+	old := m
+	old.currentPage = t.from
+	from := old.paintPage()
+	progress := float64(t.tick) / float64(max(1, m.show.length))
+	// Ease in and out so the change starts and settles gently.
+	progress = progress * progress * (3 - 2*progress)
+
+	switch t.style {
+	case dissolve:
+		return dissolveGrids(from, to, progress)
+	case pixelSort:
+		return pixelSortGrids(from, to, progress)
+	case zoom:
+		return zoomGrids(from, to, progress)
+	default:
+		return wipeGrids(from, to, progress, menagerie.Moods[m.scene.Mood].Accent)
+	}
+}
+
+// wipeGrids sweeps the new page in from the left behind a bright edge.
+func wipeGrids(from, to [][]menagerie.Pixel, progress float64, edge lipgloss.Color) [][]menagerie.Pixel {
+	for y := range to {
+		cut := int(progress * float64(len(to[y])))
+		for x := cut; x < len(to[y]); x++ {
+			to[y][x] = from[y][x]
+		}
+		if cut < len(to[y]) && progress > 0 {
+			to[y][cut] = menagerie.Pixel{Char: "▌", Color: edge}
+		}
+	}
+	return to
+}
+
+// dissolveGrids swaps cells over in a fixed scattered order so each one
+// flips exactly once.
+func dissolveGrids(from, to [][]menagerie.Pixel, progress float64) [][]menagerie.Pixel {
+	for y := range to {
+		for x := range to[y] {
+			if cellNoise(x, y) >= progress {
+				to[y][x] = from[y][x]
+			}
+		}
+	}
+	return to
+}
+
+// cellNoise is a cheap hash of a cell's position onto 0 to 1.
+func cellNoise(x, y int) float64 {
+	h := uint32(x)*374761393 + uint32(y)*668265263
+	h = (h ^ (h >> 13)) * 1274126177
+	h ^= h >> 16
+	return float64(h%10007) / 10007
+}
+
+// pixelSortGrids sorts each row of the old page by brightness, row by row
+// down the screen, then unsorts the new page the same way.
+func pixelSortGrids(from, to [][]menagerie.Pixel, progress float64) [][]menagerie.Pixel {
+	grid, amount := from, progress*2
+	if progress >= 0.5 {
+		grid, amount = to, (1-progress)*2
+	}
+	for y, row := range grid {
+		// Lower rows start later, so the sort rains down the screen.
+		stagger := float64(y) / float64(max(1, len(grid))) * 0.5
+		span := int(math.Min(1, math.Max(0, (amount-stagger)/0.5)) * float64(len(row)))
+		sorted := append([]menagerie.Pixel(nil), row[:span]...)
+		sort.SliceStable(sorted, func(i, j int) bool { return pixelWeight(sorted[i]) < pixelWeight(sorted[j]) })
+		copy(row, sorted)
+	}
+	return grid
+}
+
+func pixelWeight(p menagerie.Pixel) float64 {
+	if p.Char == " " {
+		return -1
+	}
+	return menagerie.Luminance(p.Color)
+}
+
+// zoomGrids dives into the centre of the old page, then pulls back out of
+// the new one.
+func zoomGrids(from, to [][]menagerie.Pixel, progress float64) [][]menagerie.Pixel {
+	src, depth := from, progress*2
+	if progress >= 0.5 {
+		src, depth = to, (1-progress)*2
+	}
+	if len(src) == 0 || len(src[0]) == 0 {
+		return to
+	}
+	scale := 1 + 5*depth
+	out := menagerie.NewGrid(len(src[0]), len(src))
+	cx, cy := float64(len(src[0]))/2, float64(len(src))/2
+	for y := range out {
+		for x := range out[y] {
+			sx := int(cx + (float64(x)-cx)/scale)
+			sy := int(cy + (float64(y)-cy)/scale)
+			out[y][x] = src[sy][sx]
+		}
+	}
+	return out
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+	"math/rand"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/tempo"
+)
+
+// spawnBeatPulse restarts the next ring in rotation. Downbeats launch from the
+// creature itself so the bar is easy to feel; other beats land at random.
+func (m *model) spawnBeatPulse() {
+	x := rand.Float64() * float64(m.width)
+	y := rand.Float64() * float64(m.height)
+	if m.clock.BeatInBar() == 0 {
+		x, y = float64(m.creatureX), float64(m.creatureY)
+	}
+	// Size the expansion so a ring fades out right as the bar ends.
+	ticksPerBar := m.clock.BeatSeconds() * tempo.BeatsPerBar / tickInterval.Seconds()
+	m.scene.LaunchPulse(x, y, 15/ticksPerBar)
+}
+
+func (m model) beatIndicator() string {
+	if m.clock.Locked {
+		return m.clock.Indicator() + " lock"
+	}
+	return m.clock.Indicator() + " free"
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"time"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+)
+
+// frame is an editable pose. Every frame is padded to the canvas size so
+// painting never has to grow a ragged row.
+type frame struct {
+	name     string
+	pixels   [][]rune
+	duration time.Duration
+}
+
+func blankFrame(name string, width, height int) frame {
+	f := frame{name: name, duration: sprite.DefaultDuration, pixels: make([][]rune, height)}
+	for y := range f.pixels {
+		f.pixels[y] = make([]rune, width)
+		for x := range f.pixels[y] {
+			f.pixels[y][x] = sprite.Transparent
+		}
+	}
+	return f
+}
+
+func (f frame) clone() frame {
+	out := f
+	out.pixels = make([][]rune, len(f.pixels))
+	for y, row := range f.pixels {
+		out.pixels[y] = append([]rune(nil), row...)
+	}
+	return out
+}
+
+func cloneFrames(frames []frame) []frame {
+	out := make([]frame, len(frames))
+	for i, f := range frames {
+		out[i] = f.clone()
+	}
+	return out
+}
+
+// framesFromSprite copies s's frames onto a width by height canvas.
+func framesFromSprite(s *sprite.Sprite) []frame {
+	frames := make([]frame, len(s.Frames))
+	for i, sf := range s.Frames {
+		f := blankFrame(sf.Name, s.Width, s.Height)
+		f.duration = sf.Duration
+		for y, row := range sf.Rows {
+			for x, g := range []rune(row) {
+				if !sprite.IsTransparent(g) {
+					f.pixels[y][x] = g
+				}
+			}
+		}
+		frames[i] = f
+	}
+	return frames
+}
+
+// fill floods the 4-connected region of matching glyphs around x, y with
+// glyph.
+func (f frame) fill(x, y int, glyph rune) {
+	target := f.pixels[y][x]
+	if target == glyph {
+		return
+	}
+	stack := [][2]int{{x, y}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		px, py := p[0], p[1]
+		if py < 0 || py >= len(f.pixels) || px < 0 || px >= len(f.pixels[py]) || f.pixels[py][px] != target {
+			continue
+		}
+		f.pixels[py][px] = glyph
+		stack = append(stack, [2]int{px + 1, py}, [2]int{px - 1, py}, [2]int{px, py + 1}, [2]int{px, py - 1})
+	}
+}
+
+// flip mirrors the frame left to right.
+func (f frame) flip() {
+	for _, row := range f.pixels {
+		for i, j := 0, len(row)-1; i < j; i, j = i+1, j-1 {
+			row[i], row[j] = row[j], row[i]
+		}
+	}
+}
+
+func (f frame) rows() []string {
+	rows := make([]string, len(f.pixels))
+	for y, row := range f.pixels {
+		rows[y] = string(row)
+	}
+	return rows
+}
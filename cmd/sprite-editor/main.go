@@ -0,0 +1,402 @@
+// Command sprite-editor paints .sprite files frame by frame, with a live
+// preview of the animation over one of nyan-cat's backgrounds.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const tickInterval = 80 * time.Millisecond
+
+// durationStep is how much + and - change the current frame's duration.
+const durationStep = 20 * time.Millisecond
+
+// maxUndo bounds the undo history.
+const maxUndo = 64
+
+type tickMsg struct{}
+
+type model struct {
+	width  int
+	height int
+
+	path   string
+	name   string
+	mode   sprite.Mode
+	colors map[rune]string // palette entries read from the file
+
+	canvasW, canvasH int
+	anchorX, anchorY int
+	frames           []frame
+	current          int
+	undo             [][]frame
+
+	cursorX, cursorY int
+	swatches         []rune
+	swatch           int
+	mirror           bool
+	onion            bool
+
+	backdrop menagerie.Background
+	scene    menagerie.Scene
+	elapsed  time.Duration
+
+	dirty     bool
+	quitArmed bool
+	status    string
+}
+
+func newModel(path string, width, height int, backdrop menagerie.Background) model {
+	m := model{
+		path:     path,
+		name:     strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)),
+		colors:   map[rune]string{},
+		canvasW:  width,
+		canvasH:  height,
+		anchorX:  width / 2,
+		anchorY:  height / 2,
+		frames:   []frame{blankFrame("0", width, height)},
+		onion:    true,
+		backdrop: backdrop,
+	}
+	m.resetScene()
+	m.refreshSwatches()
+	return m
+}
+
+// load replaces the canvas with the sprite at m.path.
+func (m *model) load() error {
+	f, err := os.Open(m.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s, err := sprite.Parse(filepath.Base(m.path), f)
+	if err != nil {
+		return err
+	}
+	m.name = s.Name
+	m.mode = s.Mode
+	m.colors = s.Palette
+	m.canvasW, m.canvasH = s.Width, s.Height
+	m.anchorX, m.anchorY = s.AnchorX, s.AnchorY
+	m.frames = framesFromSprite(s)
+	m.current = 0
+	m.undo = nil
+	m.cursorX, m.cursorY = 0, 0
+	m.dirty = false
+	m.resetScene()
+	m.refreshSwatches()
+	return nil
+}
+
+func (m model) save() error {
+	var buf bytes.Buffer
+	if err := sprite.Encode(&buf, m.sprite()); err != nil {
+		return err
+	}
+	return os.WriteFile(m.path, buf.Bytes(), 0o644)
+}
+
+// sprite assembles the canvas into a sprite. The palette is cut down to the
+// glyphs the frames use, so picking a colour and never painting with it
+// leaves no trace in the file.
+func (m model) sprite() *sprite.Sprite {
+	s := &sprite.Sprite{
+		Name:    m.name,
+		Palette: map[rune]string{},
+		Mode:    m.mode,
+		AnchorX: m.anchorX,
+		AnchorY: m.anchorY,
+		Width:   m.canvasW,
+		Height:  m.canvasH,
+	}
+	for _, f := range m.frames {
+		for _, row := range f.pixels {
+			for _, g := range row {
+				if c, ok := m.colorOf(g); ok && !sprite.IsTransparent(g) {
+					s.Palette[g] = c
+				}
+			}
+		}
+		s.Frames = append(s.Frames, sprite.Frame{Name: f.name, Rows: f.rows(), Duration: f.duration})
+	}
+	return s
+}
+
+// colorOf prefers the file's own colour for a glyph over the standard one.
+func (m model) colorOf(glyph rune) (string, bool) {
+	if c, ok := m.colors[glyph]; ok {
+		return c, true
+	}
+	return sprite.StandardColor(glyph)
+}
+
+// refreshSwatches lists the standard palette followed by any other glyph the
+// file colours or draws with.
+func (m *model) refreshSwatches() {
+	seen := map[rune]bool{}
+	m.swatches = m.swatches[:0]
+	for _, sw := range sprite.Standard {
+		m.swatches = append(m.swatches, sw.Glyph)
+		seen[sw.Glyph] = true
+	}
+	var extra []rune
+	add := func(g rune) {
+		if !seen[g] && !sprite.IsTransparent(g) {
+			seen[g] = true
+			extra = append(extra, g)
+		}
+	}
+	for g := range m.colors {
+		add(g)
+	}
+	for _, f := range m.frames {
+		for _, row := range f.pixels {
+			for _, g := range row {
+				add(g)
+			}
+		}
+	}
+	sort.Slice(extra, func(i, j int) bool { return extra[i] < extra[j] })
+	m.swatches = append(m.swatches, extra...)
+	if m.swatch >= len(m.swatches) {
+		m.swatch = 0
+	}
+}
+
+func (m *model) resetScene() {
+	w, h := m.previewSize()
+	m.scene = menagerie.New(w, h)
+}
+
+// previewSize leaves room around the sprite, which the preview draws at
+// double width as nyan-cat does.
+func (m model) previewSize() (int, int) {
+	return max(2*m.canvasW+16, 40), m.canvasH + 6
+}
+
+func (m model) glyph() rune {
+	return m.swatches[m.swatch]
+}
+
+func (m *model) checkpoint() {
+	m.undo = append(m.undo, cloneFrames(m.frames))
+	if len(m.undo) > maxUndo {
+		m.undo = m.undo[1:]
+	}
+	m.dirty = true
+}
+
+// paint sets the pixel under the cursor, and its reflection when mirroring.
+func (m *model) paint(glyph rune) {
+	m.checkpoint()
+	f := m.frames[m.current]
+	f.pixels[m.cursorY][m.cursorX] = glyph
+	if m.mirror {
+		f.pixels[m.cursorY][m.canvasW-1-m.cursorX] = glyph
+	}
+}
+
+func (m *model) fill() {
+	m.checkpoint()
+	f := m.frames[m.current]
+	f.fill(m.cursorX, m.cursorY, m.glyph())
+	if m.mirror {
+		f.fill(m.canvasW-1-m.cursorX, m.cursorY, m.glyph())
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	return tick()
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tickMsg:
+		m.elapsed += tickInterval
+		m.scene.Step(0.016, false)
+		return m, tick()
+
+	case tea.KeyMsg:
+		key := msg.String()
+		if key != "q" && key != "ctrl+c" {
+			m.quitArmed = false
+			m.status = ""
+		}
+		switch key {
+		case "q", "ctrl+c":
+			if m.dirty && !m.quitArmed {
+				m.quitArmed = true
+				m.status = "unsaved changes: press q again to quit, ctrl+s to save"
+				return m, nil
+			}
+			return m, tea.Quit
+		case "up", "k":
+			m.cursorY = (m.cursorY - 1 + m.canvasH) % m.canvasH
+		case "down", "j":
+			m.cursorY = (m.cursorY + 1) % m.canvasH
+		case "left", "h":
+			m.cursorX = (m.cursorX - 1 + m.canvasW) % m.canvasW
+		case "right", "l":
+			m.cursorX = (m.cursorX + 1) % m.canvasW
+		case " ", "enter":
+			m.paint(m.glyph())
+		case "x", "backspace", "delete":
+			m.paint(sprite.Transparent)
+		case "f":
+			m.fill()
+		case "i":
+			g := m.frames[m.current].pixels[m.cursorY][m.cursorX]
+			for i, s := range m.swatches {
+				if s == g {
+					m.swatch = i
+				}
+			}
+		case "[":
+			m.swatch = (m.swatch - 1 + len(m.swatches)) % len(m.swatches)
+		case "]":
+			m.swatch = (m.swatch + 1) % len(m.swatches)
+		case "m":
+			m.mirror = !m.mirror
+		case "M":
+			m.checkpoint()
+			m.frames[m.current].flip()
+		case "o":
+			m.onion = !m.onion
+		case ",":
+			m.current = (m.current - 1 + len(m.frames)) % len(m.frames)
+		case ".":
+			m.current = (m.current + 1) % len(m.frames)
+		case "a", "d":
+			m.checkpoint()
+			f := blankFrame("", m.canvasW, m.canvasH)
+			if key == "d" {
+				f = m.frames[m.current].clone()
+			}
+			f.name = fmt.Sprint(len(m.frames))
+			m.frames = append(m.frames[:m.current+1], append([]frame{f}, m.frames[m.current+1:]...)...)
+			m.current++
+		case "D":
+			if len(m.frames) == 1 {
+				m.status = "a sprite needs at least one frame"
+				break
+			}
+			m.checkpoint()
+			m.frames = append(m.frames[:m.current], m.frames[m.current+1:]...)
+			m.current = min(m.current, len(m.frames)-1)
+		case "+", "=":
+			m.checkpoint()
+			m.frames[m.current].duration += durationStep
+		case "-":
+			if m.frames[m.current].duration > durationStep {
+				m.checkpoint()
+				m.frames[m.current].duration -= durationStep
+			}
+		case "p":
+			m.dirty = true
+			if m.mode == sprite.Loop {
+				m.mode = sprite.PingPong
+			} else {
+				m.mode = sprite.Loop
+			}
+		case "A":
+			m.dirty = true
+			m.anchorX, m.anchorY = m.cursorX, m.cursorY
+		case "b":
+			m.backdrop = (m.backdrop + 1) % menagerie.BackgroundCount
+		case "B":
+			m.backdrop = (m.backdrop - 1 + menagerie.BackgroundCount) % menagerie.BackgroundCount
+		case "u":
+			if n := len(m.undo); n > 0 {
+				m.frames = m.undo[n-1]
+				m.undo = m.undo[:n-1]
+				m.current = min(m.current, len(m.frames)-1)
+			}
+		case "ctrl+s":
+			if err := m.save(); err != nil {
+				m.status = "save failed: " + err.Error()
+			} else {
+				m.dirty = false
+				m.status = "saved " + m.path
+			}
+		case "ctrl+r":
+			if err := m.load(); err != nil {
+				m.status = "reload failed: " + err.Error()
+			} else {
+				m.status = "reloaded " + m.path
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(tickInterval, func(time.Time) tea.Msg {
+		return tickMsg{}
+	})
+}
+
+func parseBackdrop(name string) (menagerie.Background, error) {
+	for b := menagerie.Background(0); b < menagerie.BackgroundCount; b++ {
+		if b.String() == name {
+			return b, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown backdrop %q", name)
+}
+
+func main() {
+	width := flag.Int("width", 20, "canvas width for a new sprite")
+	height := flag.Int("height", 14, "canvas height for a new sprite")
+	backdropName := flag.String("backdrop", menagerie.Underwater.String(), "nyan-cat background to preview over")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: sprite-editor [flags] file.sprite")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *width < 1 || *height < 1 {
+		fmt.Println("Error: -width and -height must be positive")
+		os.Exit(2)
+	}
+	backdrop, err := parseBackdrop(*backdropName)
+	if err != nil {
+		fmt.Println("Error:", err)
+		os.Exit(2)
+	}
+
+	m := newModel(flag.Arg(0), *width, *height, backdrop)
+	if err := m.load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		fmt.Println("Error loading sprite:", err)
+		os.Exit(1)
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	if _, err := p.Run(); err != nil {
+		fmt.Println("Error running program:", err)
+	}
+}
@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/menagerie"
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// swatchesPerRow keeps the palette strip narrower than the canvas pane.
+const swatchesPerRow = 13
+
+var (
+	titleStyle  = lipgloss.NewStyle().Foreground(lipgloss.Color("213")).Bold(true)
+	dimStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	statusStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("220"))
+	pickedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(lipgloss.Color("57")).Bold(true)
+	checkerDark = lipgloss.NewStyle().Background(lipgloss.Color("234"))
+	checkerLite = lipgloss.NewStyle().Background(lipgloss.Color("236"))
+)
+
+func (m model) View() string {
+	canvas := lipgloss.JoinVertical(lipgloss.Left, titleStyle.Render(m.title()), m.renderCanvas())
+	preview := lipgloss.JoinVertical(lipgloss.Left,
+		titleStyle.Render("preview • "+m.backdrop.String()),
+		m.renderPreview(),
+	)
+	panes := lipgloss.JoinHorizontal(lipgloss.Top, canvas, "    ", preview)
+
+	status := m.status
+	if status == "" {
+		status = fmt.Sprintf("(%d,%d) • %s • mirror %s • onion %s • anchor %d,%d",
+			m.cursorX, m.cursorY, m.swatchLabel(), onOff(m.mirror), onOff(m.onion), m.anchorX, m.anchorY)
+	}
+	help := dimStyle.Render("arrows/hjkl move • space paint • x erase • f fill • i pick • [ ] colour • m mirror • M flip\n" +
+		", . frame • a add • d duplicate • D delete • +/- duration • p loop/pingpong • o onion • A anchor\n" +
+		"b/B backdrop • u undo • ctrl+s save • ctrl+r reload • q quit")
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		panes,
+		"",
+		m.renderTimeline(),
+		m.renderPalette(),
+		"",
+		statusStyle.Render(status),
+		help,
+	)
+}
+
+func (m model) title() string {
+	mode := "loop"
+	if m.mode == sprite.PingPong {
+		mode = "pingpong"
+	}
+	title := fmt.Sprintf("%s • %dx%d • %s", m.path, m.canvasW, m.canvasH, mode)
+	if m.dirty {
+		title += " *"
+	}
+	return title
+}
+
+func (m model) swatchLabel() string {
+	g := m.glyph()
+	for _, sw := range sprite.Standard {
+		if sw.Glyph == g {
+			if c, _ := m.colorOf(g); c == sw.Color {
+				return fmt.Sprintf("%c %s", g, sw.Name)
+			}
+		}
+	}
+	if c, ok := m.colorOf(g); ok {
+		return fmt.Sprintf("%c %s", g, c)
+	}
+	return fmt.Sprintf("%c text", g)
+}
+
+// renderCanvas draws each pixel two cells wide over a checkerboard. With
+// onion skinning on, the previous frame shows faintly through transparent
+// pixels.
+func (m model) renderCanvas() string {
+	f := m.frames[m.current]
+	var under *frame
+	if m.onion && len(m.frames) > 1 {
+		under = &m.frames[(m.current-1+len(m.frames))%len(m.frames)]
+	}
+
+	var b strings.Builder
+	for y, row := range f.pixels {
+		for x, g := range row {
+			cursor := x == m.cursorX && y == m.cursorY
+			b.WriteString(m.renderPixel(g, x, y, under, cursor))
+		}
+		if y < len(f.pixels)-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+func (m model) renderPixel(g rune, x, y int, under *frame, cursor bool) string {
+	checker := checkerDark
+	if (x+y)%2 == 1 {
+		checker = checkerLite
+	}
+	if cursor {
+		bg := lipgloss.Color("236")
+		if c, ok := m.colorOf(g); ok && !sprite.IsTransparent(g) {
+			bg = lipgloss.Color(c)
+		}
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(bg).Bold(true).Render("[]")
+	}
+	if sprite.IsTransparent(g) {
+		if under != nil {
+			if c, ok := m.colorOf(under.pixels[y][x]); ok && !sprite.IsTransparent(under.pixels[y][x]) {
+				return checker.Foreground(lipgloss.Color(c)).Render("░░")
+			}
+		}
+		if x == m.anchorX && y == m.anchorY {
+			return checker.Foreground(lipgloss.Color("244")).Render("+ ")
+		}
+		return checker.Render("  ")
+	}
+	if c, ok := m.colorOf(g); ok {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color(c)).Render("██")
+	}
+	return checker.Render(string(g) + " ")
+}
+
+// renderPreview plays the animation over a live nyan-cat background, placed
+// by its anchor as nyan-cat places its creatures.
+func (m model) renderPreview() string {
+	w, h := m.previewSize()
+	grid := menagerie.NewGrid(w, h)
+	m.scene.Paint(grid, m.backdrop)
+
+	s := m.sprite()
+	cx, cy := w/2, h/2
+	for y, row := range s.FrameAt(m.elapsed).Rows {
+		py := cy - s.AnchorY + y
+		for x, g := range []rune(row) {
+			px := cx + (x-s.AnchorX)*2
+			c, ok := s.Color(g)
+			if !ok || py < 0 || py >= h || px < 0 || px+1 >= w {
+				continue
+			}
+			grid[py][px] = menagerie.Pixel{Char: "█", Color: lipgloss.Color(c)}
+			grid[py][px+1] = grid[py][px]
+		}
+	}
+
+	var b strings.Builder
+	for y, row := range grid {
+		for _, p := range row {
+			if p.Char == " " {
+				b.WriteString(" ")
+			} else {
+				b.WriteString(lipgloss.NewStyle().Foreground(p.Color).Render(p.Char))
+			}
+		}
+		if y < len(grid)-1 {
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+func (m model) renderTimeline() string {
+	cells := make([]string, len(m.frames))
+	for i, f := range m.frames {
+		label := fmt.Sprintf(" %d:%s %s ", i+1, f.name, f.duration)
+		if i == m.current {
+			cells[i] = pickedStyle.Render(label)
+		} else {
+			cells[i] = dimStyle.Render(label)
+		}
+	}
+	return "frames " + strings.Join(cells, " ")
+}
+
+func (m model) renderPalette() string {
+	var lines []string
+	var line strings.Builder
+	for i, g := range m.swatches {
+		if i > 0 && i%swatchesPerRow == 0 {
+			lines = append(lines, line.String())
+			line.Reset()
+		}
+		label := string(g)
+		if i == m.swatch {
+			label = pickedStyle.Render(label)
+		}
+		block := "??"
+		if c, ok := m.colorOf(g); ok {
+			block = lipgloss.NewStyle().Foreground(lipgloss.Color(c)).Render("██")
+		}
+		line.WriteString(" " + label + block)
+	}
+	lines = append(lines, line.String())
+	return "colour" + strings.Join(lines, "\n      ")
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
@@ -0,0 +1,258 @@
+// Command sprite-import converts a small PNG or animated GIF into the .sprite
+// format nyan-cat and critter-carnival draw from.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	_ "image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ThomasVuNguyen/charm-experiments/internal/sprite"
+)
+
+// glyphs are handed out to palette entries from darkest to lightest.
+const glyphs = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+
+// maxSide keeps imports to something a terminal can show. Even at one cell per
+// pixel a 128 pixel sprite fills a wide window.
+const maxSide = 128
+
+type options struct {
+	colors   int
+	alpha    int
+	stretch  bool
+	trim     bool
+	mode     sprite.Mode
+	name     string
+	duration time.Duration
+}
+
+func main() {
+	colors := flag.Int("colors", 8, fmt.Sprintf("palette size, 1 to %d", len(glyphs)))
+	alpha := flag.Int("alpha", 128, "pixels with alpha below this become transparent")
+	stretch := flag.Bool("stretch", false, "double every pixel horizontally, as nyan-cat does when it draws, so square art keeps its shape in one-cell-per-pixel programs")
+	trim := flag.Bool("trim", true, "crop transparent margins shared by every frame")
+	mode := flag.String("mode", "loop", "animation mode: loop or pingpong")
+	name := flag.String("name", "", "sprite name (defaults to the file name)")
+	duration := flag.Duration("frame-duration", sprite.DefaultDuration, "duration for PNGs and GIF frames without a delay")
+	out := flag.String("o", "", "write the sprite here instead of stdout")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "usage: sprite-import [flags] image.png|animation.gif")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	opts := options{colors: *colors, alpha: *alpha, stretch: *stretch, trim: *trim, name: *name, duration: *duration}
+	switch *mode {
+	case "loop":
+		opts.mode = sprite.Loop
+	case "pingpong":
+		opts.mode = sprite.PingPong
+	default:
+		fail(fmt.Errorf("unknown mode %q (want loop or pingpong)", *mode))
+	}
+	if opts.colors < 1 || opts.colors > len(glyphs) {
+		fail(fmt.Errorf("-colors must be between 1 and %d", len(glyphs)))
+	}
+
+	path := flag.Arg(0)
+	if opts.name == "" {
+		opts.name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	frames, delays, err := decode(path)
+	if err != nil {
+		fail(err)
+	}
+	s, err := convert(frames, delays, opts)
+	if err != nil {
+		fail(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			fail(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	fmt.Fprintf(w, "# imported from %s\n", filepath.Base(path))
+	if err := sprite.Encode(w, s); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "sprite-import:", err)
+	os.Exit(1)
+}
+
+// decode reads every frame of the image, already composited, along with each
+// frame's delay. Still images have one frame and no delay.
+func decode(path string) ([]*image.NRGBA, []time.Duration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	if g, err := gif.DecodeAll(bytes.NewReader(data)); err == nil {
+		frames := compositeGIF(g)
+		delays := make([]time.Duration, len(frames))
+		for i := range delays {
+			if i < len(g.Delay) {
+				delays[i] = time.Duration(g.Delay[i]) * 10 * time.Millisecond
+			}
+		}
+		return frames, delays, nil
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return []*image.NRGBA{toNRGBA(img)}, []time.Duration{0}, nil
+}
+
+// compositeGIF plays a GIF's frames onto one canvas, honouring each frame's
+// disposal, so partial frames come out whole.
+func compositeGIF(g *gif.GIF) []*image.NRGBA {
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+	if bounds.Empty() && len(g.Image) > 0 {
+		bounds = g.Image[0].Bounds()
+	}
+	canvas := image.NewNRGBA(bounds)
+	frames := make([]*image.NRGBA, 0, len(g.Image))
+	for i, src := range g.Image {
+		disposal := byte(0)
+		if i < len(g.Disposal) {
+			disposal = g.Disposal[i]
+		}
+		var previous *image.NRGBA
+		if disposal == gif.DisposalPrevious {
+			previous = cloneNRGBA(canvas)
+		}
+		draw.Draw(canvas, src.Bounds(), src, src.Bounds().Min, draw.Over)
+		frames = append(frames, cloneNRGBA(canvas))
+		switch disposal {
+		case gif.DisposalBackground:
+			draw.Draw(canvas, src.Bounds(), image.Transparent, image.Point{}, draw.Src)
+		case gif.DisposalPrevious:
+			canvas = previous
+		}
+	}
+	return frames
+}
+
+func toNRGBA(img image.Image) *image.NRGBA {
+	b := img.Bounds()
+	out := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(out, out.Bounds(), img, b.Min, draw.Src)
+	return out
+}
+
+func cloneNRGBA(img *image.NRGBA) *image.NRGBA {
+	out := image.NewNRGBA(img.Bounds())
+	copy(out.Pix, img.Pix)
+	return out
+}
+
+// convert quantises the frames to one shared palette and lays them out as
+// glyph rows.
+func convert(frames []*image.NRGBA, delays []time.Duration, opts options) (*sprite.Sprite, error) {
+	if len(frames) == 0 {
+		return nil, errors.New("image has no frames")
+	}
+	area := frames[0].Bounds()
+	if opts.trim {
+		area = opaqueBounds(frames, opts.alpha)
+		if area.Empty() {
+			return nil, errors.New("every pixel is transparent")
+		}
+	}
+	if area.Dx() > maxSide || area.Dy() > maxSide {
+		return nil, fmt.Errorf("image is %dx%d; sprites over %d pixels on a side will not fit a terminal, scale it down first", area.Dx(), area.Dy(), maxSide)
+	}
+
+	var pixels []rgb
+	for _, f := range frames {
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			for x := area.Min.X; x < area.Max.X; x++ {
+				if c, a := toRGB(f.At(x, y)); int(a) >= opts.alpha {
+					pixels = append(pixels, c)
+				}
+			}
+		}
+	}
+	palette := medianCut(pixels, opts.colors)
+	sort.Slice(palette, func(i, j int) bool { return luminance(palette[i]) < luminance(palette[j]) })
+	glyphOf := []rune(glyphs)
+
+	s := &sprite.Sprite{Name: opts.name, Palette: map[rune]string{}, Mode: opts.mode}
+	for i, c := range palette {
+		s.Palette[glyphOf[i]] = fmt.Sprintf("#%02X%02X%02X", c[0], c[1], c[2])
+	}
+	// Quantising the same colour over and over is the slow part.
+	lookup := map[rgb]rune{}
+	for i, f := range frames {
+		frame := sprite.Frame{Name: fmt.Sprint(i), Duration: opts.duration}
+		if delays[i] > 0 {
+			frame.Duration = delays[i]
+		}
+		for y := area.Min.Y; y < area.Max.Y; y++ {
+			var row strings.Builder
+			for x := area.Min.X; x < area.Max.X; x++ {
+				g := sprite.Transparent
+				if c, a := toRGB(f.At(x, y)); int(a) >= opts.alpha {
+					var ok bool
+					if g, ok = lookup[c]; !ok {
+						g = glyphOf[nearest(palette, c)]
+						lookup[c] = g
+					}
+				}
+				row.WriteRune(g)
+				if opts.stretch {
+					row.WriteRune(g)
+				}
+			}
+			frame.Rows = append(frame.Rows, row.String())
+		}
+		s.Frames = append(s.Frames, frame)
+	}
+	s.Width, s.Height = area.Dx(), area.Dy()
+	if opts.stretch {
+		s.Width *= 2
+	}
+	s.AnchorX, s.AnchorY = s.Width/2, s.Height/2
+	return s, nil
+}
+
+// opaqueBounds is the smallest rectangle holding every visible pixel of every
+// frame, so trimming never clips part of the animation.
+func opaqueBounds(frames []*image.NRGBA, alpha int) image.Rectangle {
+	var area image.Rectangle
+	for _, f := range frames {
+		b := f.Bounds()
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			for x := b.Min.X; x < b.Max.X; x++ {
+				if _, a := toRGB(f.At(x, y)); int(a) >= alpha {
+					area = area.Union(image.Rect(x, y, x+1, y+1))
+				}
+			}
+		}
+	}
+	return area
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"image"
+	"image/color"
+	"image/gif"
+	"testing"
+)
+
+// TestCompositeGIF plays a 4x1 GIF through each disposal method. Every frame
+// after the first paints a single pixel.
+func TestCompositeGIF(t *testing.T) {
+	var (
+		clear = color.NRGBA{}
+		red   = color.NRGBA{255, 0, 0, 255}
+		blue  = color.NRGBA{0, 0, 255, 255}
+		green = color.NRGBA{0, 255, 0, 255}
+		white = color.NRGBA{255, 255, 255, 255}
+	)
+	palette := color.Palette{clear, red, blue, green, white}
+	frame := func(x0, x1 int, c color.Color) *image.Paletted {
+		img := image.NewPaletted(image.Rect(x0, 0, x1, 1), palette)
+		for x := x0; x < x1; x++ {
+			img.Set(x, 0, c)
+		}
+		return img
+	}
+	g := &gif.GIF{
+		Image: []*image.Paletted{
+			frame(0, 4, red),
+			frame(1, 2, blue),
+			frame(2, 3, green),
+			frame(3, 4, white),
+		},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalBackground, gif.DisposalPrevious, gif.DisposalNone},
+		Config:   image.Config{Width: 4, Height: 1},
+	}
+	want := [][]color.NRGBA{
+		{red, red, red, red},
+		{red, blue, red, red},    // then cleared to the background
+		{red, clear, green, red}, // then put back as it was
+		{red, clear, red, white},
+	}
+
+	frames := compositeGIF(g)
+	if len(frames) != len(want) {
+		t.Fatalf("got %d frames, want %d", len(frames), len(want))
+	}
+	for i, img := range frames {
+		for x, c := range want[i] {
+			if got := img.NRGBAAt(x, 0); got != c {
+				t.Errorf("frame %d pixel %d is %v, want %v", i, x, got, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"image/color"
+	"sort"
+)
+
+// rgb is an opaque colour with 8-bit channels.
+type rgb [3]uint8
+
+func toRGB(c color.Color) (rgb, uint8) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return rgb{n.R, n.G, n.B}, n.A
+}
+
+// colorBox is a set of colours that median cut may split further.
+type colorBox struct {
+	colors []rgb
+}
+
+// widest returns the channel with the largest spread and that spread.
+func (b colorBox) widest() (int, int) {
+	best, spread := 0, -1
+	for ch := 0; ch < 3; ch++ {
+		lo, hi := 255, 0
+		for _, c := range b.colors {
+			lo = min(lo, int(c[ch]))
+			hi = max(hi, int(c[ch]))
+		}
+		if hi-lo > spread {
+			best, spread = ch, hi-lo
+		}
+	}
+	return best, spread
+}
+
+func (b colorBox) average() rgb {
+	var sum [3]int
+	for _, c := range b.colors {
+		for ch := range sum {
+			sum[ch] += int(c[ch])
+		}
+	}
+	n := len(b.colors)
+	return rgb{uint8((sum[0] + n/2) / n), uint8((sum[1] + n/2) / n), uint8((sum[2] + n/2) / n)}
+}
+
+// medianCut reduces pixels to at most n colours. It repeatedly splits the box
+// with the widest channel spread at that channel's median, then averages each
+// box. Pixels are counted with repetition so large areas pull the palette
+// toward their colour.
+func medianCut(pixels []rgb, n int) []rgb {
+	if len(pixels) == 0 || n <= 0 {
+		return nil
+	}
+	boxes := []colorBox{{colors: append([]rgb(nil), pixels...)}}
+	for len(boxes) < n {
+		pick, pickSpread, pickChannel := -1, 0, 0
+		for i, b := range boxes {
+			if len(b.colors) < 2 {
+				continue
+			}
+			ch, spread := b.widest()
+			if spread > pickSpread {
+				pick, pickSpread, pickChannel = i, spread, ch
+			}
+		}
+		if pick < 0 {
+			break // every box is a single colour already
+		}
+		colors := boxes[pick].colors
+		sort.Slice(colors, func(i, j int) bool { return colors[i][pickChannel] < colors[j][pickChannel] })
+		// Keep equal values together so a box never splits inside one colour:
+		// move the cut up from the median to the next change of value, or down
+		// when the median's value runs to the end.
+		mid := len(colors) / 2
+		for mid < len(colors) && colors[mid][pickChannel] == colors[mid-1][pickChannel] {
+			mid++
+		}
+		if mid == len(colors) {
+			mid = len(colors) / 2
+			for colors[mid][pickChannel] == colors[mid-1][pickChannel] {
+				mid--
+			}
+		}
+		boxes[pick] = colorBox{colors: colors[:mid]}
+		boxes = append(boxes, colorBox{colors: colors[mid:]})
+	}
+	palette := make([]rgb, len(boxes))
+	for i, b := range boxes {
+		palette[i] = b.average()
+	}
+	return palette
+}
+
+// nearest returns the index of the palette colour closest to c.
+func nearest(palette []rgb, c rgb) int {
+	best, bestDist := 0, -1
+	for i, p := range palette {
+		dist := 0
+		for ch := range p {
+			d := int(p[ch]) - int(c[ch])
+			dist += d * d
+		}
+		if bestDist < 0 || dist < bestDist {
+			best, bestDist = i, dist
+		}
+	}
+	return best
+}
+
+// luminance orders palette entries dark to light so glyph letters read
+// roughly as shades.
+func luminance(c rgb) int {
+	return 299*int(c[0]) + 587*int(c[1]) + 114*int(c[2])
+}
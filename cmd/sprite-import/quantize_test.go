@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func sortedPalette(p []rgb) []rgb {
+	out := append([]rgb(nil), p...)
+	sort.Slice(out, func(i, j int) bool { return luminance(out[i]) < luminance(out[j]) })
+	return out
+}
+
+func TestMedianCutSize(t *testing.T) {
+	var ramp []rgb
+	for i := 0; i < 256; i++ {
+		ramp = append(ramp, rgb{uint8(i), uint8(255 - i), uint8(i / 2)})
+	}
+	tests := []struct {
+		name   string
+		pixels []rgb
+		n      int
+		want   int
+	}{
+		{"empty", nil, 4, 0},
+		{"no colours asked for", ramp, 0, 0},
+		{"one colour", []rgb{{9, 9, 9}, {9, 9, 9}}, 4, 1},
+		{"fewer colours than asked", []rgb{{0, 0, 0}, {255, 0, 0}, {0, 255, 0}}, 8, 3},
+		{"reduced", ramp, 16, 16},
+		{"reduced to one", ramp, 1, 1},
+	}
+	for _, tt := range tests {
+		if got := medianCut(tt.pixels, tt.n); len(got) != tt.want {
+			t.Errorf("%s: got %d colours, want %d", tt.name, len(got), tt.want)
+		}
+	}
+}
+
+// TestMedianCutIdentity checks that an image with no more colours than the
+// palette allows keeps every colour exactly, however often each appears.
+func TestMedianCutIdentity(t *testing.T) {
+	colors := []rgb{{0, 0, 0}, {255, 255, 255}, {255, 0, 0}, {0, 0, 255}, {10, 200, 30}}
+	var pixels []rgb
+	for i, c := range colors {
+		for j := 0; j <= i*7; j++ {
+			pixels = append(pixels, c)
+		}
+	}
+	got := medianCut(pixels, len(colors))
+	if !reflect.DeepEqual(sortedPalette(got), sortedPalette(colors)) {
+		t.Errorf("medianCut = %v, want %v", got, colors)
+	}
+	for _, c := range colors {
+		if p := got[nearest(got, c)]; p != c {
+			t.Errorf("%v maps to %v", c, p)
+		}
+	}
+}
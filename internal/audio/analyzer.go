@@ -0,0 +1,154 @@
+package audio
+
+import "math"
+
+const (
+	windowSize   = 1024
+	bassCutoff   = 250.0
+	trebleCutoff = 4000.0
+	fluxHistory  = 43
+	minOnsetGap  = 0.12
+)
+
+// Frame is the per-frame reading the visuals react to. Band values are
+// normalised against a slowly decaying peak so they sit roughly in [0, 1]
+// regardless of the input level.
+type Frame struct {
+	Bass   float64
+	Mid    float64
+	Treble float64
+	Level  float64
+	Onset  bool
+}
+
+// Analyzer slides an FFT window over a Track following the animation clock.
+type Analyzer struct {
+	track  *Track
+	window []float64
+	buf    []complex128
+	prev   []float64
+	mags   []float64
+
+	flux      []float64
+	fluxIdx   int
+	peaks     [4]float64
+	smooth    Frame
+	lastOnset float64
+	lastT     float64
+}
+
+// NewAnalyzer prepares an analyzer over track. The track loops when the clock
+// runs past its end.
+func NewAnalyzer(track *Track) *Analyzer {
+	a := &Analyzer{
+		track:     track,
+		window:    hannWindow(windowSize),
+		buf:       make([]complex128, windowSize),
+		prev:      make([]float64, windowSize/2),
+		mags:      make([]float64, windowSize/2),
+		flux:      make([]float64, fluxHistory),
+		lastOnset: -1,
+	}
+	for i := range a.peaks {
+		a.peaks[i] = 1e-3
+	}
+	return a
+}
+
+// At analyses the window starting at t seconds into the track.
+func (a *Analyzer) At(t float64) Frame {
+	if a == nil || a.track == nil || len(a.track.Samples) == 0 {
+		return Frame{}
+	}
+	if t < a.lastT {
+		a.lastOnset = -1
+	}
+	a.lastT = t
+
+	samples := a.track.Samples
+	rate := float64(a.track.SampleRate)
+	start := int(t*rate) % len(samples)
+	if start < 0 {
+		start += len(samples)
+	}
+	for i := 0; i < windowSize; i++ {
+		s := samples[(start+i)%len(samples)]
+		a.buf[i] = complex(s*a.window[i], 0)
+	}
+	fft(a.buf)
+
+	binHz := rate / windowSize
+	var bass, mid, treble, level, flux float64
+	var nBass, nMid, nTreble int
+	for i := 1; i < windowSize/2; i++ {
+		re, im := real(a.buf[i]), imag(a.buf[i])
+		mag := math.Sqrt(re*re+im*im) / windowSize
+		a.mags[i] = mag
+		if d := mag - a.prev[i]; d > 0 {
+			flux += d
+		}
+		energy := mag * mag
+		level += energy
+		hz := float64(i) * binHz
+		switch {
+		case hz < bassCutoff:
+			bass += energy
+			nBass++
+		case hz < trebleCutoff:
+			mid += energy
+			nMid++
+		default:
+			treble += energy
+			nTreble++
+		}
+	}
+	a.prev, a.mags = a.mags, a.prev
+
+	raw := [4]float64{
+		rms(bass, nBass),
+		rms(mid, nMid),
+		rms(treble, nTreble),
+		rms(level, windowSize/2-1),
+	}
+	var norm [4]float64
+	for i, v := range raw {
+		a.peaks[i] = math.Max(a.peaks[i]*0.997, v)
+		norm[i] = math.Min(v/a.peaks[i], 1)
+	}
+
+	a.smooth.Bass = follow(a.smooth.Bass, norm[0])
+	a.smooth.Mid = follow(a.smooth.Mid, norm[1])
+	a.smooth.Treble = follow(a.smooth.Treble, norm[2])
+	a.smooth.Level = follow(a.smooth.Level, norm[3])
+
+	var mean float64
+	for _, f := range a.flux {
+		mean += f
+	}
+	mean /= float64(len(a.flux))
+	a.flux[a.fluxIdx] = flux
+	a.fluxIdx = (a.fluxIdx + 1) % len(a.flux)
+
+	frame := a.smooth
+	if flux > mean*1.6 && flux > 1e-4 && (a.lastOnset < 0 || t-a.lastOnset >= minOnsetGap) {
+		frame.Onset = true
+		a.lastOnset = t
+	}
+	return frame
+}
+
+func rms(sum float64, n int) float64 {
+	if n <= 0 {
+		return 0
+	}
+	return math.Sqrt(sum / float64(n))
+}
+
+// follow is a fast-attack, slow-release envelope so pulses snap on hits and
+// settle gracefully.
+func follow(current, target float64) float64 {
+	if target > current {
+		return current + (target-current)*0.6
+	}
+	return current + (target-current)*0.12
+}
@@ -0,0 +1,201 @@
+package audio
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// Track is a fully decoded mono signal held in memory so the analyzer can seek
+// freely without an audio device.
+type Track struct {
+	Samples    []float64
+	SampleRate int
+}
+
+// Duration reports the track length in seconds.
+func (t *Track) Duration() float64 {
+	if t == nil || t.SampleRate == 0 {
+		return 0
+	}
+	return float64(len(t.Samples)) / float64(t.SampleRate)
+}
+
+// Open loads a WAV file from path, or raw s16le PCM from stdin when path is "-".
+// sampleRate and channels only apply to raw PCM, which carries no header.
+func Open(path string, sampleRate, channels int) (*Track, error) {
+	if path == "-" {
+		return DecodePCM(bufio.NewReader(os.Stdin), sampleRate, channels)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return DecodeWAV(bufio.NewReader(f))
+}
+
+// DecodePCM reads interleaved signed 16-bit little-endian samples until EOF.
+func DecodePCM(r io.Reader, sampleRate, channels int) (*Track, error) {
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("audio: invalid sample rate %d", sampleRate)
+	}
+	if channels <= 0 {
+		return nil, fmt.Errorf("audio: invalid channel count %d", channels)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	samples := mixDown(data, 16, channels)
+	if len(samples) == 0 {
+		return nil, errors.New("audio: no PCM samples on input")
+	}
+	return &Track{Samples: samples, SampleRate: sampleRate}, nil
+}
+
+// maxFmtChunk is the size of the largest fmt chunk, WAVE_FORMAT_EXTENSIBLE's.
+// Anything bigger is corrupt, and is refused before it is allocated.
+const maxFmtChunk = 40
+
+// DecodeWAV parses a RIFF/WAVE stream holding integer PCM (8, 16, 24 or 32
+// bit) or 32-bit float samples.
+func DecodeWAV(r io.Reader) (*Track, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("audio: reading RIFF header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, errors.New("audio: not a RIFF/WAVE file")
+	}
+
+	var (
+		format     uint16
+		channels   int
+		sampleRate int
+		bits       int
+		haveFormat bool
+	)
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, errors.New("audio: WAV has no data chunk")
+			}
+			return nil, err
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			if size > maxFmtChunk {
+				return nil, fmt.Errorf("audio: fmt chunk too large (%d bytes)", size)
+			}
+			body := make([]byte, size)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return nil, fmt.Errorf("audio: reading fmt chunk: %w", err)
+			}
+			if len(body) < 16 {
+				return nil, errors.New("audio: truncated fmt chunk")
+			}
+			format = binary.LittleEndian.Uint16(body[0:2])
+			channels = int(binary.LittleEndian.Uint16(body[2:4]))
+			sampleRate = int(binary.LittleEndian.Uint32(body[4:8]))
+			bits = int(binary.LittleEndian.Uint16(body[14:16]))
+			if format == 0xFFFE && len(body) >= 26 {
+				format = binary.LittleEndian.Uint16(body[24:26])
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, errors.New("audio: data chunk before fmt chunk")
+			}
+			data, err := io.ReadAll(io.LimitReader(r, size))
+			if err != nil {
+				return nil, fmt.Errorf("audio: reading data chunk: %w", err)
+			}
+			var samples []float64
+			switch {
+			case format == 1:
+				samples = mixDown(data, bits, channels)
+			case format == 3 && bits == 32:
+				samples = mixDownFloat(data, channels)
+			default:
+				return nil, fmt.Errorf("audio: unsupported WAV encoding (format %d, %d-bit)", format, bits)
+			}
+			if sampleRate <= 0 || len(samples) == 0 {
+				return nil, errors.New("audio: WAV contains no samples")
+			}
+			return &Track{Samples: samples, SampleRate: sampleRate}, nil
+		default:
+			if _, err := io.CopyN(io.Discard, r, size); err != nil {
+				return nil, fmt.Errorf("audio: skipping %q chunk: %w", id, err)
+			}
+		}
+		if size%2 == 1 && id != "data" {
+			if _, err := io.CopyN(io.Discard, r, 1); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+func mixDown(data []byte, bits, channels int) []float64 {
+	width := bits / 8
+	if width < 1 || width > 4 || channels < 1 {
+		return nil
+	}
+	frame := width * channels
+	count := len(data) / frame
+	out := make([]float64, count)
+	for i := 0; i < count; i++ {
+		var sum float64
+		for c := 0; c < channels; c++ {
+			off := i*frame + c*width
+			sum += intSample(data[off:off+width], bits)
+		}
+		out[i] = sum / float64(channels)
+	}
+	return out
+}
+
+func intSample(b []byte, bits int) float64 {
+	switch bits {
+	case 8:
+		return (float64(b[0]) - 128) / 128
+	case 16:
+		return float64(int16(binary.LittleEndian.Uint16(b))) / 32768
+	case 24:
+		v := int32(b[0]) | int32(b[1])<<8 | int32(b[2])<<16
+		if v&0x800000 != 0 {
+			v |= ^0xFFFFFF
+		}
+		return float64(v) / 8388608
+	case 32:
+		return float64(int32(binary.LittleEndian.Uint32(b))) / 2147483648
+	}
+	return 0
+}
+
+func mixDownFloat(data []byte, channels int) []float64 {
+	if channels < 1 {
+		return nil
+	}
+	frame := 4 * channels
+	count := len(data) / frame
+	out := make([]float64, count)
+	for i := 0; i < count; i++ {
+		var sum float64
+		for c := 0; c < channels; c++ {
+			off := i*frame + c*4
+			sum += float64(math.Float32frombits(binary.LittleEndian.Uint32(data[off : off+4])))
+		}
+		out[i] = sum / float64(channels)
+	}
+	return out
+}
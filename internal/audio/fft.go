@@ -0,0 +1,44 @@
+package audio
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// fft performs an in-place iterative radix-2 transform. len(buf) must be a
+// power of two.
+func fft(buf []complex128) {
+	n := len(buf)
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			buf[i], buf[j] = buf[j], buf[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		half := size / 2
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < half; k++ {
+				even := buf[start+k]
+				odd := buf[start+k+half] * w
+				buf[start+k] = even + odd
+				buf[start+k+half] = even - odd
+				w *= step
+			}
+		}
+	}
+}
+
+func hannWindow(n int) []float64 {
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n-1))
+	}
+	return w
+}
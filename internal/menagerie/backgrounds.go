@@ -0,0 +1,689 @@
+package menagerie
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Background names one of the scene painters.
+type Background int
+
+const (
+	Harmonic Background = iota
+	Underwater
+	Desert
+	Mechanical
+	Bioluminescent
+	Crystalline
+	Noodle
+	Psychedelic
+	Aerial
+	Geometric
+	Void
+	Prismatic
+	Forest
+	Mental
+	BackgroundCount
+)
+
+var backgroundNames = [BackgroundCount]string{
+	"harmonic", "underwater", "desert", "mechanical", "bioluminescent", "crystalline", "noodle",
+	"psychedelic", "aerial", "geometric", "void", "prismatic", "forest", "mental",
+}
+
+func (b Background) String() string {
+	if b < 0 || b >= BackgroundCount {
+		return "unknown"
+	}
+	return backgroundNames[b]
+}
+
+// Paint fills grid with background b drawn from the scene's current state,
+// tinted by the active mood.
+func (s *Scene) Paint(grid [][]Pixel, b Background) {
+	switch b {
+	case Underwater:
+		s.drawUnderwaterBackground(grid)
+	case Desert:
+		s.drawDesertBackground(grid)
+	case Mechanical:
+		s.drawMechanicalBackground(grid)
+	case Bioluminescent:
+		s.drawBioluminescentBackground(grid)
+	case Crystalline:
+		s.drawCrystallineBackground(grid)
+	case Noodle:
+		s.drawNoodleBackground(grid)
+	case Psychedelic:
+		s.drawPsychedelicBackground(grid)
+	case Aerial:
+		s.drawAerialBackground(grid)
+	case Geometric:
+		s.drawGeometricBackground(grid)
+	case Void:
+		s.drawVoidBackground(grid)
+	case Prismatic:
+		s.drawPrismaticBackground(grid)
+	case Forest:
+		s.drawForestBackground(grid)
+	case Mental:
+		s.drawMentalBackground(grid)
+	default:
+		// The harmonic field draws straight from the mood palette already.
+		s.drawHarmonicBackground(grid)
+		return
+	}
+	s.applyMood(grid)
+}
+
+func (s *Scene) drawHarmonicBackground(grid [][]Pixel) {
+	mood := Moods[s.Mood]
+
+	// Fill background with spaces (empty ASCII background)
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: mood.Background}
+		}
+	}
+
+	// Generate flowing ASCII field using sine waves and flow fields
+	threshold := s.noiseThreshold()
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			// Create layered noise field for ASCII selection
+			noise1 := math.Sin(float64(x)*0.1+s.Time*0.8) * math.Cos(float64(y)*0.15+s.Time*0.6)
+			noise2 := math.Sin(float64(x)*0.2+float64(y)*0.1+s.Time*1.2) * 0.7
+			noise3 := math.Cos(float64(x)*0.05+float64(y)*0.08+s.Time*0.4) * 0.5
+
+			combined := (noise1 + noise2 + noise3) / 3.0
+
+			// Map noise to ASCII characters and intensity
+			intensity := math.Abs(combined)
+
+			if intensity > threshold {
+				// Select ASCII character based on noise value and mood
+				var char string
+				var color lipgloss.Color
+
+				if intensity < 0.4 {
+					// Subtle background characters
+					chars := []string{".", "·", ":", ";", "'", "`"}
+					char = chars[int(math.Abs(combined*100))%len(chars)]
+					color = mood.Palette[0]
+				} else if intensity < 0.6 {
+					// Medium intensity - flowing patterns
+					chars := []string{"~", "-", "=", "≈", "∼", "⌒", "∿"}
+					char = chars[int(math.Abs(combined*100))%len(chars)]
+					color = mood.Palette[1]
+				} else if intensity < 0.8 {
+					// Higher intensity - complex patterns
+					chars := []string{"∞", "◊", "⟡", "⧨", "⬢", "◯", "◈"}
+					char = chars[int(math.Abs(combined*100))%len(chars)]
+					color = mood.Palette[2]
+				} else {
+					// Highest intensity - accent characters
+					char = string(mood.Glyphs[int(math.Abs(combined*100))%len(mood.Glyphs)])
+					color = mood.Accent
+				}
+
+				grid[y][x] = Pixel{Char: char, Color: color}
+			}
+		}
+	}
+
+	// Draw harmonic waves as ASCII trails
+	for _, wave := range s.particles("harmonics") {
+		for t := 0.0; t < 6.28; t += 0.3 {
+			x := wave.X + wave.Size*math.Cos(t+wave.Phase)
+			y := wave.Y + wave.Size*math.Sin(t*wave.Spin+wave.Phase)
+
+			ix, iy := int(x), int(y)
+			if ix >= 0 && ix < s.Width && iy >= 0 && iy < s.Height {
+				intensity := math.Abs(math.Sin(t + wave.Phase))
+				if intensity > 0.4 {
+					// Use flowing wave characters
+					waveChars := []string{"~", "≈", "∿", "⌒", "∼"}
+					char := waveChars[int(t*5)%len(waveChars)]
+					grid[iy][ix] = Pixel{Char: char, Color: wave.Color}
+				}
+			}
+		}
+	}
+
+	// Draw flow field particles as drifting ASCII
+	for _, p := range s.particles("flow") {
+		x, y := int(p.X), int(p.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height && p.Energy > 0.3 {
+			grid[y][x] = Pixel{Char: string(p.Glyph), Color: p.Color}
+		}
+	}
+
+	// Draw energy orbs as ASCII mandalas
+	for _, orb := range s.particles("orbs") {
+		cx, cy := int(orb.X), int(orb.Y)
+		r := int((1 + 2*orb.Energy) * orb.Energy)
+
+		if r > 0 {
+			for dy := -r; dy <= r; dy++ {
+				for dx := -r; dx <= r; dx++ {
+					distance := math.Sqrt(float64(dx*dx + dy*dy))
+					if distance <= float64(r) {
+						x, y := cx+dx, cy+dy
+						if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+							alpha := 1.0 - distance/float64(r)
+							if alpha > 0.6 {
+								// Inner core
+								grid[y][x] = Pixel{Char: "●", Color: orb.Color}
+							} else if alpha > 0.3 {
+								// Middle ring
+								grid[y][x] = Pixel{Char: "○", Color: orb.Color}
+							} else if alpha > 0.1 {
+								// Outer ring
+								grid[y][x] = Pixel{Char: "·", Color: orb.Color}
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Draw spirals as ASCII curves
+	for _, spiral := range s.particles("spirals") {
+		for i := 0.0; i < spiral.Size && i < 20; i += 0.8 {
+			angle := spiral.Phase + i*0.3
+			x := spiral.X + i*math.Cos(angle)
+			y := spiral.Y + i*math.Sin(angle)
+
+			ix, iy := int(x), int(y)
+			if ix >= 0 && ix < s.Width && iy >= 0 && iy < s.Height {
+				// Choose spiral character based on angle
+				spiralChars := []string{"◦", "⋄", "◊", "⬢", "⬟", "⟡"}
+				char := spiralChars[int(i)%len(spiralChars)]
+				grid[iy][ix] = Pixel{Char: char, Color: spiral.Color}
+			}
+		}
+	}
+
+	// Draw pulses as expanding ASCII rings
+	for _, pulse := range s.particles("pulses") {
+		if pulse.Energy > 0.1 {
+			cx, cy := int(pulse.X), int(pulse.Y)
+			r := int(pulse.Size)
+
+			if r > 0 && r < 20 {
+				// Draw ASCII circle
+				for angle := 0.0; angle < 6.28; angle += 0.4 {
+					x := cx + int(float64(r)*math.Cos(angle))
+					y := cy + int(float64(r)*math.Sin(angle))
+
+					if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+						// Choose ring character based on pulse intensity
+						if pulse.Energy > 0.7 {
+							grid[y][x] = Pixel{Char: "◉", Color: pulse.Color}
+						} else if pulse.Energy > 0.4 {
+							grid[y][x] = Pixel{Char: "◯", Color: pulse.Color}
+						} else {
+							grid[y][x] = Pixel{Char: "○", Color: pulse.Color}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	// Draw wisps as flowing ASCII trails
+	for _, wisp := range s.particles("wisps") {
+		for i, point := range wisp.Trail {
+			if point.Alpha > 0.1 {
+				x, y := int(point.X), int(point.Y)
+				if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+					// Use different trail characters based on position in trail
+					trailChars := []string{"·", ":", "∶", "⁚", "‥", "…", "⋯", "⋱"}
+					if i < len(trailChars) {
+						grid[y][x] = Pixel{Char: trailChars[i], Color: wisp.Color}
+					}
+				}
+			}
+		}
+	}
+}
+
+// drawParticles paints each of the named effect's particles as its own glyph
+// and colour, for effects whose look lives entirely in their emitter.
+func (s *Scene) drawParticles(grid [][]Pixel, name string) {
+	for _, p := range s.particles(name) {
+		x, y := int(p.X), int(p.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height && p.Glyph != 0 {
+			grid[y][x] = Pixel{Char: string(p.Glyph), Color: p.Color}
+		}
+	}
+}
+
+// Underwater background for Jellyfish Horse - flowing currents and bubbles
+func (s *Scene) drawUnderwaterBackground(grid [][]Pixel) {
+	// Deep ocean blue base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("17")}
+		}
+	}
+
+	// Flowing water currents
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			currentX := math.Sin(float64(x)*0.1+s.Time*1.5) * math.Cos(float64(y)*0.2+s.Time*0.8)
+			currentY := math.Cos(float64(x)*0.15+s.Time*1.2) * math.Sin(float64(y)*0.1+s.Time*1.0)
+			intensity := math.Abs(currentX + currentY)
+
+			if intensity > 0.3 {
+				if intensity < 0.5 {
+					grid[y][x] = Pixel{Char: "~", Color: lipgloss.Color("39")}
+				} else if intensity < 0.7 {
+					grid[y][x] = Pixel{Char: "≈", Color: lipgloss.Color("45")}
+				} else {
+					grid[y][x] = Pixel{Char: "∿", Color: lipgloss.Color("51")}
+				}
+			}
+		}
+	}
+
+	// Floating bubbles
+	for _, orb := range s.particles("orbs") {
+		x, y := int(orb.X), int(orb.Y-s.Time*10) // Bubbles rise
+		if y < 0 {
+			y += s.Height
+		} // Wrap around
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+			bubbleChars := []string{"○", "◯", "◦", "∘"}
+			char := bubbleChars[int(orb.Phase*4)%len(bubbleChars)]
+			grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("87")}
+		}
+	}
+}
+
+// Desert background for Cactus Octopus - sand patterns and heat waves
+func (s *Scene) drawDesertBackground(grid [][]Pixel) {
+	// Sandy base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("58")}
+		}
+	}
+
+	// Sand dune patterns
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			dune := math.Sin(float64(x)*0.2+s.Time*0.3) * math.Cos(float64(y)*0.1)
+			wind := math.Sin(float64(x)*0.05+float64(y)*0.1+s.Time*2.0) * 0.8
+			combined := math.Abs(dune + wind)
+
+			if combined > 0.3 {
+				if combined < 0.5 {
+					grid[y][x] = Pixel{Char: ".", Color: lipgloss.Color("220")}
+				} else if combined < 0.7 {
+					grid[y][x] = Pixel{Char: ":", Color: lipgloss.Color("226")}
+				} else {
+					grid[y][x] = Pixel{Char: "∴", Color: lipgloss.Color("208")}
+				}
+			}
+		}
+	}
+
+	// Heat shimmers
+	for _, wave := range s.particles("harmonics") {
+		for t := 0.0; t < 6.28; t += 0.4 {
+			x := wave.X + wave.Size*math.Sin(t+wave.Phase*2)
+			y := wave.Y + wave.Size*0.3*math.Cos(t*2+wave.Phase)
+			ix, iy := int(x), int(y)
+			if ix >= 0 && ix < s.Width && iy >= 0 && iy < s.Height {
+				shimmers := []string{"'", "`", "\"", "'"}
+				char := shimmers[int(t*2)%len(shimmers)]
+				grid[iy][ix] = Pixel{Char: char, Color: lipgloss.Color("226")}
+			}
+		}
+	}
+}
+
+// Mechanical background for Clockwork Butterfly - gears and steam
+func (s *Scene) drawMechanicalBackground(grid [][]Pixel) {
+	// Dark metal base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("240")}
+		}
+	}
+
+	// Gear mechanisms
+	for y := 0; y < s.Height; y += 6 {
+		for x := 0; x < s.Width; x += 8 {
+			if rand.Float64() > 0.4 {
+				rotation := s.Time * 2.0
+				gearChars := []string{"⚙", "⊕", "⊗", "⊙"}
+				char := gearChars[int(rotation*4)%len(gearChars)]
+				if x < s.Width && y < s.Height {
+					grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("244")}
+				}
+			}
+		}
+	}
+
+	// Steam pipes and pressure
+	for _, pulse := range s.particles("pulses") {
+		x, y := int(pulse.X), int(pulse.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+			steamChars := []string{"│", "┃", "║", "▓"}
+			char := steamChars[int(pulse.Energy*4)%len(steamChars)]
+			grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("248")}
+		}
+	}
+
+	// Rivets and bolts
+	for x := 3; x < s.Width; x += 7 {
+		for y := 2; y < s.Height; y += 5 {
+			if x < s.Width && y < s.Height {
+				grid[y][x] = Pixel{Char: "•", Color: lipgloss.Color("242")}
+			}
+		}
+	}
+
+	// Sparks off the gears
+	s.drawParticles(grid, "sparks")
+}
+
+// Bioluminescent background for Glowmushroom Sloth
+func (s *Scene) drawBioluminescentBackground(grid [][]Pixel) {
+	// Dark forest base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("22")}
+		}
+	}
+
+	// Glowing spores
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			spore := math.Sin(float64(x)*0.3+s.Time*3.0) * math.Cos(float64(y)*0.2+s.Time*2.5)
+			glow := math.Abs(spore)
+
+			if glow > 0.4 {
+				if glow < 0.6 {
+					grid[y][x] = Pixel{Char: "·", Color: lipgloss.Color("46")}
+				} else if glow < 0.8 {
+					grid[y][x] = Pixel{Char: "∘", Color: lipgloss.Color("82")}
+				} else {
+					grid[y][x] = Pixel{Char: "◉", Color: lipgloss.Color("120")}
+				}
+			}
+		}
+	}
+
+	// Mushroom caps
+	for _, orb := range s.particles("orbs") {
+		x, y := int(orb.X), int(orb.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+			grid[y][x] = Pixel{Char: "🍄", Color: lipgloss.Color("206")}
+		}
+	}
+}
+
+// Crystalline background for Crystal Spider
+func (s *Scene) drawCrystallineBackground(grid [][]Pixel) {
+	// Crystal cave base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("54")}
+		}
+	}
+
+	// Crystal formations
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			crystal := math.Sin(float64(x)*0.4+s.Time*0.5) * math.Cos(float64(y)*0.3+s.Time*0.8)
+			refraction := math.Abs(crystal)
+
+			if refraction > 0.3 {
+				crystalChars := []string{"◊", "⬟", "⟡", "◈", "⬢", "⌬"}
+				char := crystalChars[int(refraction*20)%len(crystalChars)]
+				colors := []lipgloss.Color{lipgloss.Color("129"), lipgloss.Color("93"), lipgloss.Color("201")}
+				color := colors[int(refraction*10)%len(colors)]
+				grid[y][x] = Pixel{Char: char, Color: color}
+			}
+		}
+	}
+}
+
+// Flowing noodle background for Noodle Whale
+func (s *Scene) drawNoodleBackground(grid [][]Pixel) {
+	// Brothy base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("178")}
+		}
+	}
+
+	// Flowing noodles
+	for _, wisp := range s.particles("wisps") {
+		for i, point := range wisp.Trail {
+			x, y := int(point.X), int(point.Y)
+			if x >= 0 && x < s.Width && y >= 0 && y < s.Height && i < 6 {
+				noodleChars := []string{"∿", "～", "〜", "⌇", "≋", "∽"}
+				grid[y][x] = Pixel{Char: noodleChars[i%len(noodleChars)], Color: lipgloss.Color("226")}
+			}
+		}
+	}
+
+	// Steam bubbles
+	for _, orb := range s.particles("orbs") {
+		x, y := int(orb.X), int(orb.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+			grid[y][x] = Pixel{Char: "○", Color: lipgloss.Color("15")}
+		}
+	}
+}
+
+// Psychedelic background for Eyestalk Turtle
+func (s *Scene) drawPsychedelicBackground(grid [][]Pixel) {
+	// Trippy base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("53")}
+		}
+	}
+
+	// Kaleidoscope patterns
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			pattern := math.Sin(float64(x)*0.2+s.Time*4.0) * math.Cos(float64(y)*0.2+s.Time*3.0)
+			spiral := math.Sin(math.Sqrt(float64(x*x+y*y))*0.3 + s.Time*2.0)
+			combined := math.Abs(pattern + spiral)
+
+			if combined > 0.3 {
+				psychChars := []string{"◐", "◑", "◒", "◓", "●", "○", "◉"}
+				char := psychChars[int(combined*20)%len(psychChars)]
+				colors := []lipgloss.Color{lipgloss.Color("201"), lipgloss.Color("93"), lipgloss.Color("129"), lipgloss.Color("219")}
+				color := colors[int(combined*15)%len(colors)]
+				grid[y][x] = Pixel{Char: char, Color: color}
+			}
+		}
+	}
+}
+
+// Aerial background for Feather Fish
+func (s *Scene) drawAerialBackground(grid [][]Pixel) {
+	// Sky base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("39")}
+		}
+	}
+
+	// Wind currents
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			wind := math.Sin(float64(x)*0.1+s.Time*2.0) * math.Cos(float64(y)*0.05+s.Time*1.5)
+			if math.Abs(wind) > 0.4 {
+				windChars := []string{"~", "≈", "⌇", "∼"}
+				char := windChars[int(math.Abs(wind)*10)%len(windChars)]
+				grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("87")}
+			}
+		}
+	}
+
+	// Floating feathers
+	for _, p := range s.particles("flow") {
+		x, y := int(p.X), int(p.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height && p.Energy > 0.5 {
+			grid[y][x] = Pixel{Char: "❋", Color: lipgloss.Color("15")}
+		}
+	}
+}
+
+// Geometric background for Geometric Bee
+func (s *Scene) drawGeometricBackground(grid [][]Pixel) {
+	// Grid base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("0")}
+		}
+	}
+
+	// Hexagonal honeycomb
+	for y := 0; y < s.Height; y += 4 {
+		for x := 0; x < s.Width; x += 6 {
+			if x < s.Width && y < s.Height {
+				pulse := math.Sin(s.Time*3.0 + float64(x+y)*0.1)
+				if pulse > 0 {
+					grid[y][x] = Pixel{Char: "⬢", Color: lipgloss.Color("226")}
+				}
+			}
+		}
+	}
+
+	// Geometric lines
+	for _, pulse := range s.particles("pulses") {
+		x, y := int(pulse.X), int(pulse.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+			lineChars := []string{"─", "│", "╱", "╲"}
+			char := lineChars[int(pulse.Energy*4)%len(lineChars)]
+			grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("46")}
+		}
+	}
+}
+
+// Void background for Void Squid
+func (s *Scene) drawVoidBackground(grid [][]Pixel) {
+	// Pure darkness
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("0")}
+		}
+	}
+
+	// Sparse void particles
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			void := math.Sin(float64(x)*0.01+s.Time*0.1) * math.Cos(float64(y)*0.01+s.Time*0.2)
+			if math.Abs(void) > 0.8 {
+				voidChars := []string{".", "·", "∘", "○"}
+				char := voidChars[int(math.Abs(void)*10)%len(voidChars)]
+				grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("240")}
+			}
+		}
+	}
+}
+
+// Prismatic background for Prismatic Worm
+func (s *Scene) drawPrismaticBackground(grid [][]Pixel) {
+	// Rainbow refraction base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("0")}
+		}
+	}
+
+	// Light spectrum
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			spectrum := math.Sin(float64(x)*0.3+s.Time*2.0) * math.Cos(float64(y)*0.2+s.Time*1.5)
+			refraction := math.Abs(spectrum)
+
+			if refraction > 0.3 {
+				spectrumChars := []string{"▀", "▄", "█", "░", "▒", "▓"}
+				char := spectrumChars[int(refraction*20)%len(spectrumChars)]
+				rainbowColors := []lipgloss.Color{
+					lipgloss.Color("196"), lipgloss.Color("208"), lipgloss.Color("226"),
+					lipgloss.Color("46"), lipgloss.Color("51"), lipgloss.Color("21"), lipgloss.Color("93"),
+				}
+				color := rainbowColors[int(refraction*30)%len(rainbowColors)]
+				grid[y][x] = Pixel{Char: char, Color: color}
+			}
+		}
+	}
+}
+
+// Forest background for Tentacle Tree
+func (s *Scene) drawForestBackground(grid [][]Pixel) {
+	// Dark forest floor
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("22")}
+		}
+	}
+
+	// Creeping vines
+	for _, wisp := range s.particles("wisps") {
+		for i, point := range wisp.Trail {
+			x, y := int(point.X), int(point.Y)
+			if x >= 0 && x < s.Width && y >= 0 && y < s.Height && i < 5 {
+				vineChars := []string{"│", "┃", "║", "╎", "╏"}
+				grid[y][x] = Pixel{Char: vineChars[i%len(vineChars)], Color: lipgloss.Color("28")}
+			}
+		}
+	}
+
+	// Swaying branches
+	for y := 0; y < s.Height; y += 3 {
+		sway := math.Sin(s.Time*1.5 + float64(y)*0.2)
+		x := int(float64(s.Width/4) + sway*3)
+		if x >= 0 && x < s.Width {
+			grid[y][x] = Pixel{Char: "┬", Color: lipgloss.Color("130")}
+		}
+	}
+}
+
+// Mental/neural background for Floating Brain
+func (s *Scene) drawMentalBackground(grid [][]Pixel) {
+	// Neural network base
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			grid[y][x] = Pixel{Char: " ", Color: lipgloss.Color("17")}
+		}
+	}
+
+	// Synaptic connections
+	for _, pulse := range s.particles("pulses") {
+		x, y := int(pulse.X), int(pulse.Y)
+		if x >= 0 && x < s.Width && y >= 0 && y < s.Height {
+			if pulse.Energy > 0.5 {
+				grid[y][x] = Pixel{Char: "●", Color: lipgloss.Color("21")}
+			} else {
+				grid[y][x] = Pixel{Char: "○", Color: lipgloss.Color("39")}
+			}
+		}
+	}
+
+	// Neural pathways
+	for y := 0; y < s.Height; y++ {
+		for x := 0; x < s.Width; x++ {
+			neural := math.Sin(float64(x)*0.2+s.Time*3.0) * math.Cos(float64(y)*0.1+s.Time*2.0)
+			if math.Abs(neural) > 0.4 {
+				pathChars := []string{"─", "│", "┌", "┐", "└", "┘"}
+				char := pathChars[int(math.Abs(neural)*20)%len(pathChars)]
+				grid[y][x] = Pixel{Char: char, Color: lipgloss.Color("45")}
+			}
+		}
+	}
+}
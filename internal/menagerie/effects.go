@@ -0,0 +1,85 @@
+package menagerie
+
+import "github.com/charmbracelet/lipgloss"
+
+// Effects are the particle systems every scene starts with, stepped in this
+// order. Backgrounds find them by name, so a new effect is an entry here and
+// a line in whichever painter shows it.
+var Effects = []Emitter{
+	{
+		// Oscillators the harmonic garden traces its waves from
+		Name:     "harmonics",
+		Count:    12,
+		Phase:    Span{0, 6.28},
+		Spin:     Span{0.5, 3.5},
+		Tempo:    0.02,
+		Reactive: true,
+		Size:     Span{2, 6},
+		Field:    Orbit(0.5, 0.3),
+		Edges:    Wrap,
+		Colors:   weirdColors,
+	},
+	{
+		Name:     "flow",
+		Count:    60,
+		Velocity: Span{-1, 1},
+		Field:    Currents(0.1),
+		Drag:     0.02,
+		Edges:    Wrap,
+		Energy:   Shimmer(0.3, 0.7, 2, 0.5),
+		Colors:   weirdColors,
+		Glyphs:   Moods[0].Glyphs,
+	},
+	{
+		Name:   "orbs",
+		Count:  8,
+		Phase:  Span{0, 6.28},
+		Spin:   Span{1, 1},
+		Tempo:  0.1,
+		Energy: Throb(0.5, 0.5),
+		Colors: weirdColors,
+	},
+	{
+		// Phase is the spiral's turn and Size its reach
+		Name:    "spirals",
+		Count:   5,
+		Spin:    Span{0.1, 0.3},
+		Tempo:   1,
+		Grow:    0.5,
+		MaxSize: 20,
+		Colors:  weirdColors,
+	},
+	{
+		// Rings that spread from their centre and fade as they go
+		Name:    "pulses",
+		Count:   6,
+		Spin:    Span{0.2, 0.5},
+		Grow:    1,
+		MaxSize: 15,
+		Energy:  FadeOut,
+		Colors:  weirdColors,
+	},
+	{
+		Name:   "wisps",
+		Count:  10,
+		Speed:  Span{0.5, 1.5},
+		Field:  Meander(0.5, 0.8),
+		Edges:  Wrap,
+		Trail:  8,
+		Fade:   0.9,
+		Colors: weirdColors,
+	},
+	{
+		// Sparks thrown off the clockwork, cooling as they climb
+		Name:          "sparks",
+		Count:         14,
+		Rate:          0.4,
+		Life:          Span{12, 24},
+		Velocity:      Span{-0.3, 0.3},
+		Field:         Rise(0.05, 0.2),
+		Drag:          0.05,
+		Edges:         Kill,
+		ColorOverLife: []lipgloss.Color{"231", "226", "214", "202", "240"},
+		GlyphOverLife: []rune{'*', '+', '·', '.'},
+	},
+}
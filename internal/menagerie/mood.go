@@ -0,0 +1,66 @@
+package menagerie
+
+import "github.com/charmbracelet/lipgloss"
+
+// Mood is a colour and glyph theme the scene can be painted in.
+type Mood struct {
+	Name       string
+	Palette    []lipgloss.Color
+	Background lipgloss.Color
+	Accent     lipgloss.Color
+	Glyphs     []rune
+	Intensity  float64
+	AnimSpeed  float64 // how fast creatures play their frames
+}
+
+var (
+	Moods = []Mood{
+		{
+			Name:       "Cosmic Mutation",
+			Palette:    []lipgloss.Color{lipgloss.Color("93"), lipgloss.Color("129"), lipgloss.Color("201"), lipgloss.Color("213")},
+			Background: lipgloss.Color("0"),
+			Accent:     lipgloss.Color("15"),
+			Glyphs:     []rune{'∞', '◊', '⟡', '⧨', '⬢'},
+			Intensity:  0.8,
+			AnimSpeed:  1.0,
+		},
+		{
+			Name:       "Acid Dream",
+			Palette:    []lipgloss.Color{lipgloss.Color("196"), lipgloss.Color("226"), lipgloss.Color("46"), lipgloss.Color("51")},
+			Background: lipgloss.Color("22"),
+			Accent:     lipgloss.Color("15"),
+			Glyphs:     []rune{'~', '≈', '∿', '◯', '◉'},
+			Intensity:  1.2,
+			AnimSpeed:  1.6,
+		},
+		{
+			Name:       "Void Ripple",
+			Palette:    []lipgloss.Color{lipgloss.Color("240"), lipgloss.Color("244"), lipgloss.Color("248"), lipgloss.Color("15")},
+			Background: lipgloss.Color("0"),
+			Accent:     lipgloss.Color("93"),
+			Glyphs:     []rune{'·', '⋅', '∘', '○', '●'},
+			Intensity:  0.6,
+			AnimSpeed:  0.5,
+		},
+		{
+			Name:       "Neural Bloom",
+			Palette:    []lipgloss.Color{lipgloss.Color("21"), lipgloss.Color("39"), lipgloss.Color("45"), lipgloss.Color("87")},
+			Background: lipgloss.Color("17"),
+			Accent:     lipgloss.Color("201"),
+			Glyphs:     []rune{'※', '⚡', '✦', '❋', '✧'},
+			Intensity:  0.9,
+			AnimSpeed:  1.2,
+		},
+	}
+
+	weirdColors = []lipgloss.Color{
+		lipgloss.Color("93"),  // Mystic purple
+		lipgloss.Color("201"), // Neon pink
+		lipgloss.Color("226"), // Electric yellow
+		lipgloss.Color("51"),  // Cyber cyan
+		lipgloss.Color("196"), // Acid red
+		lipgloss.Color("129"), // Void magenta
+		lipgloss.Color("46"),  // Alien green
+		lipgloss.Color("21"),  // Deep space blue
+	}
+)
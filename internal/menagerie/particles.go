@@ -0,0 +1,308 @@
+package menagerie
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Span is a range a value is drawn from at random. A zero Span always gives
+// zero.
+type Span struct{ Min, Max float64 }
+
+func (r Span) pick() float64 {
+	return r.Min + rand.Float64()*(r.Max-r.Min)
+}
+
+// Edges decides what happens to a particle that leaves the scene.
+type Edges int
+
+const (
+	Free Edges = iota // carry on off screen
+	Wrap              // come back in on the far side
+	Kill              // die and make room for a new one
+)
+
+// Particle is one point of an effect. Fields an emitter does not use stay
+// zero, and backgrounds read whichever ones their effect drives.
+type Particle struct {
+	X, Y   float64
+	VX, VY float64
+	Speed  float64 // scalar speed for fields that steer by angle
+	Phase  float64 // oscillator advanced by Spin, for orbits, throbs and spirals
+	Spin   float64
+	Size   float64
+	Energy float64 // 0 to 1
+	Age    int     // steps since spawn
+	Life   int     // steps to live, or 0 to live until something else ends it
+	Index  int     // spawn order within the emitter
+	Color  lipgloss.Color
+	Glyph  rune
+	Trail  []TrailPoint // newest first
+}
+
+// TrailPoint is a remembered particle position.
+type TrailPoint struct {
+	X, Y  float64
+	Alpha float64
+}
+
+// Field steers a particle once a step, before it moves.
+type Field func(p *Particle, s *Scene)
+
+// Curve gives a particle's energy. progress runs from 0 at spawn to 1 at
+// death for particles with a lifetime or size limit.
+type Curve func(p *Particle, progress, time float64) float64
+
+// Emitter describes a particle effect as data: how many particles, where and
+// how they spawn, how they move and age, and what they look like over their
+// lives.
+type Emitter struct {
+	Name  string
+	Count int     // particles alive at once
+	Rate  float64 // spawns a step while short of Count; 0 refills at once
+	Life  Span    // steps a particle lives; zero lives for ever
+
+	Velocity Span // each velocity component at spawn
+	Speed    Span
+	Phase    Span
+	Spin     Span
+	Tempo    float64 // phase advanced a step for each unit of spin
+	Reactive bool    // treble quickens the phase when audio is playing
+	Size     Span
+	Grow     float64 // size added a step for each unit of spin
+	MaxSize  float64 // particles die once bigger; 0 for no limit
+
+	Field  Field
+	Drag   float64 // share of velocity lost a step
+	Edges  Edges
+	Trail  int     // positions remembered
+	Fade   float64 // share of trail alpha kept a step
+	Energy Curve   // nil keeps full energy
+
+	// Colors and Glyphs are picked from at random on spawn. The OverLife
+	// lists replace the pick and are walked from first to last as a
+	// particle ages.
+	Colors        []lipgloss.Color
+	Glyphs        []rune
+	ColorOverLife []lipgloss.Color
+	GlyphOverLife []rune
+}
+
+// System is an emitter and the particles it has alive.
+type System struct {
+	Emitter
+	Particles []Particle
+
+	// Hold stops the system refilling, so dead particles stay gone until
+	// Emit brings them back.
+	Hold bool
+
+	spawned int
+	owed    float64
+}
+
+// newSystem starts e's system full, unless it has a spawn rate to trickle
+// its particles in at.
+func newSystem(e Emitter, s *Scene) *System {
+	sys := &System{Emitter: e}
+	for sys.Rate <= 0 && len(sys.Particles) < sys.Count {
+		sys.spawn(s, rand.Float64()*float64(s.Width), rand.Float64()*float64(s.Height))
+	}
+	return sys
+}
+
+func (sys *System) spawn(s *Scene, x, y float64) *Particle {
+	p := Particle{
+		X:      x,
+		Y:      y,
+		VX:     sys.Velocity.pick(),
+		VY:     sys.Velocity.pick(),
+		Speed:  sys.Speed.pick(),
+		Phase:  sys.Phase.pick(),
+		Spin:   sys.Spin.pick(),
+		Size:   sys.Size.pick(),
+		Energy: 1,
+		Life:   int(sys.Life.pick()),
+		Index:  sys.spawned,
+	}
+	sys.spawned++
+	if len(sys.Colors) > 0 {
+		p.Color = sys.Colors[rand.Intn(len(sys.Colors))]
+	}
+	if len(sys.Glyphs) > 0 {
+		p.Glyph = sys.Glyphs[rand.Intn(len(sys.Glyphs))]
+	}
+	if sys.Trail > 0 {
+		p.Trail = make([]TrailPoint, sys.Trail)
+	}
+	sys.dress(&p)
+	sys.Particles = append(sys.Particles, p)
+	return &sys.Particles[len(sys.Particles)-1]
+}
+
+// Emit spawns a particle at x, y. A full system recycles its oldest particle
+// instead.
+func (sys *System) Emit(s *Scene, x, y float64) *Particle {
+	if len(sys.Particles) < sys.Count || len(sys.Particles) == 0 {
+		return sys.spawn(s, x, y)
+	}
+	oldest := 0
+	for i := range sys.Particles {
+		if sys.Particles[i].Age > sys.Particles[oldest].Age {
+			oldest = i
+		}
+	}
+	sys.Particles = append(sys.Particles[:oldest], sys.Particles[oldest+1:]...)
+	return sys.spawn(s, x, y)
+}
+
+// progress is how far through its life p is, or 0 for particles without an
+// end.
+func (sys *System) progress(p *Particle) float64 {
+	switch {
+	case p.Life > 0:
+		return math.Min(1, float64(p.Age)/float64(p.Life))
+	case sys.MaxSize > 0:
+		return math.Max(0, math.Min(1, p.Size/sys.MaxSize))
+	}
+	return 0
+}
+
+// dress sets the over-life colour and glyph.
+func (sys *System) dress(p *Particle) {
+	t := sys.progress(p)
+	if n := len(sys.ColorOverLife); n > 0 {
+		p.Color = sys.ColorOverLife[min(int(t*float64(n)), n-1)]
+	}
+	if n := len(sys.GlyphOverLife); n > 0 {
+		p.Glyph = sys.GlyphOverLife[min(int(t*float64(n)), n-1)]
+	}
+}
+
+func (sys *System) step(s *Scene) {
+	tempo := sys.Tempo
+	if sys.Reactive {
+		tempo *= s.waveRate()
+	}
+	w, h := float64(s.Width), float64(s.Height)
+
+	live := sys.Particles[:0]
+	for _, p := range sys.Particles {
+		if sys.Trail > 0 {
+			copy(p.Trail[1:], p.Trail)
+			p.Trail[0] = TrailPoint{X: p.X, Y: p.Y, Alpha: 1}
+			for j := range p.Trail {
+				p.Trail[j].Alpha *= sys.Fade
+			}
+		}
+
+		p.Age++
+		p.Phase += p.Spin * tempo
+		p.Size += p.Spin * sys.Grow
+		if sys.Field != nil {
+			sys.Field(&p, s)
+		}
+		p.VX *= 1 - sys.Drag
+		p.VY *= 1 - sys.Drag
+		p.X += p.VX
+		p.Y += p.VY
+
+		out := p.X < 0 || p.X > w || p.Y < 0 || p.Y > h
+		if out && sys.Edges == Kill {
+			continue
+		}
+		if out && sys.Edges == Wrap {
+			if p.X < 0 {
+				p.X = w
+			} else if p.X > w {
+				p.X = 0
+			}
+			if p.Y < 0 {
+				p.Y = h
+			} else if p.Y > h {
+				p.Y = 0
+			}
+		}
+
+		if sys.Energy != nil {
+			p.Energy = sys.Energy(&p, sys.progress(&p), s.Time)
+		}
+		if (p.Life > 0 && p.Age >= p.Life) || (sys.MaxSize > 0 && p.Size > sys.MaxSize) {
+			continue
+		}
+		sys.dress(&p)
+		live = append(live, p)
+	}
+	sys.Particles = live
+
+	if sys.Hold {
+		return
+	}
+	if sys.Rate <= 0 {
+		sys.owed = float64(sys.Count)
+	} else {
+		sys.owed += sys.Rate
+	}
+	for sys.owed >= 1 && len(sys.Particles) < sys.Count {
+		sys.spawn(s, rand.Float64()*w, rand.Float64()*h)
+		sys.owed--
+	}
+	sys.owed = math.Min(sys.owed, 1)
+}
+
+// Orbit swings a particle around its spawn point by its phase, dx cells
+// across and dy cells down.
+func Orbit(dx, dy float64) Field {
+	return func(p *Particle, s *Scene) {
+		p.VX = math.Sin(p.Phase) * dx
+		p.VY = math.Cos(p.Phase*1.3) * dy
+	}
+}
+
+// Currents pushes particles along a slowly turning flow field.
+func Currents(strength float64) Field {
+	return func(p *Particle, s *Scene) {
+		angle := math.Sin(p.X*0.1) + math.Cos(p.Y*0.1) + s.Time*0.5
+		p.VX += math.Cos(angle) * strength
+		p.VY += math.Sin(angle) * strength
+	}
+}
+
+// Meander sends each particle on its own looping heading at its speed.
+func Meander(turn, stagger float64) Field {
+	return func(p *Particle, s *Scene) {
+		angle := s.Time*turn + float64(p.Index)*stagger
+		p.VX = math.Cos(angle) * p.Speed
+		p.VY = math.Sin(angle*1.3) * p.Speed * 0.7
+	}
+}
+
+// Rise pulls particles upward and lets them waver side to side.
+func Rise(lift, waver float64) Field {
+	return func(p *Particle, s *Scene) {
+		p.VY -= lift
+		p.VX += (rand.Float64() - 0.5) * waver
+	}
+}
+
+// Shimmer ripples energy between base and base+depth, each particle a little
+// behind the one spawned before it.
+func Shimmer(base, depth, speed, stagger float64) Curve {
+	return func(p *Particle, progress, time float64) float64 {
+		return base + depth*math.Abs(math.Sin(time*speed+float64(p.Index)*stagger))
+	}
+}
+
+// Throb follows the particle's own phase.
+func Throb(base, depth float64) Curve {
+	return func(p *Particle, progress, time float64) float64 {
+		return base + depth*math.Sin(p.Phase)
+	}
+}
+
+// FadeOut dims a particle to nothing over its life.
+func FadeOut(p *Particle, progress, time float64) float64 {
+	return 1 - progress
+}
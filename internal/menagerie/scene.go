@@ -0,0 +1,148 @@
+// Package menagerie is nyan-cat's living backdrop: the drifting particle
+// systems, the mood themes and a painter for each creature's surreal
+// environment. It lives outside nyan-cat so other tools, such as the sprite
+// editor's preview, can paint the same scenes.
+package menagerie
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Pixel is one terminal cell of a painted scene.
+type Pixel struct {
+	Char  string
+	Color lipgloss.Color
+}
+
+// NewGrid returns a blank width by height grid to paint into.
+func NewGrid(width, height int) [][]Pixel {
+	grid := make([][]Pixel, height)
+	for i := range grid {
+		grid[i] = make([]Pixel, width)
+		for j := range grid[i] {
+			grid[i][j] = Pixel{Char: " ", Color: lipgloss.Color("0")}
+		}
+	}
+	return grid
+}
+
+// Scene holds the particle systems every background draws from.
+type Scene struct {
+	Width, Height int
+	Time          float64
+	Mood          int
+
+	// Treble is the audio's high band from 0 to 1. It only counts when
+	// Reactive is set.
+	Treble   float64
+	Reactive bool
+
+	systems []*System
+}
+
+// New seeds a scene's particles across a width by height field, one system
+// for each of the Effects.
+func New(width, height int) Scene {
+	s := Scene{Width: width, Height: height}
+	for _, e := range Effects {
+		s.systems = append(s.systems, newSystem(e, &s))
+	}
+	return s
+}
+
+// System returns the particle system for the named effect, or nil.
+func (s *Scene) System(name string) *System {
+	for _, sys := range s.systems {
+		if sys.Name == name {
+			return sys
+		}
+	}
+	return nil
+}
+
+// particles is the named effect's live particles.
+func (s *Scene) particles(name string) []Particle {
+	if sys := s.System(name); sys != nil {
+		return sys.Particles
+	}
+	return nil
+}
+
+// Step advances time by dt and moves every particle. With lockPulses set,
+// spent rings wait for LaunchPulse instead of respawning on their own.
+func (s *Scene) Step(dt float64, lockPulses bool) {
+	s.Time += dt
+	if pulses := s.System("pulses"); pulses != nil {
+		pulses.Hold = lockPulses
+	}
+	for _, sys := range s.systems {
+		sys.step(s)
+	}
+}
+
+// Swell grows every ring by amount times its own expansion rate.
+func (s *Scene) Swell(amount float64) {
+	pulses := s.System("pulses")
+	if pulses == nil {
+		return
+	}
+	for i := range pulses.Particles {
+		pulses.Particles[i].Size += pulses.Particles[i].Spin * amount
+	}
+}
+
+// Burst restarts the oldest ring at x, y.
+func (s *Scene) Burst(x, y float64) {
+	if pulses := s.System("pulses"); pulses != nil {
+		pulses.Emit(s, x, y)
+	}
+}
+
+// Fling throws a random flow particle out from x, y at up to speed cells a
+// step.
+func (s *Scene) Fling(x, y, speed float64) {
+	flow := s.System("flow")
+	if flow == nil || len(flow.Particles) == 0 {
+		return
+	}
+	p := &flow.Particles[rand.Intn(len(flow.Particles))]
+	p.X, p.Y = x, y
+	p.VX = (rand.Float64() - 0.5) * speed
+	p.VY = (rand.Float64() - 0.5) * speed
+}
+
+// LaunchPulse starts a ring at x, y growing expansion cells a step, in
+// place of the oldest one if every ring is out.
+func (s *Scene) LaunchPulse(x, y, expansion float64) {
+	if pulses := s.System("pulses"); pulses != nil {
+		pulses.Emit(s, x, y).Spin = expansion
+	}
+}
+
+// moodIntensity scales the active mood's base intensity with the treble band
+// when audio is playing.
+func (s *Scene) moodIntensity() float64 {
+	base := Moods[s.Mood].Intensity
+	if !s.Reactive {
+		return base
+	}
+	return base * (0.6 + 0.9*s.Treble)
+}
+
+// noiseThreshold lowers the cut-off for the ASCII field as intensity rises so
+// louder passages fill more of the screen.
+func (s *Scene) noiseThreshold() float64 {
+	base := Moods[s.Mood].Intensity
+	return math.Max(0.05, 0.2*base/math.Max(s.moodIntensity(), 0.1))
+}
+
+// waveRate speeds the harmonic oscillators up with the treble band.
+func (s *Scene) waveRate() float64 {
+	if !s.Reactive {
+		return 1
+	}
+	return 1 + 1.5*s.Treble
+}
@@ -0,0 +1,123 @@
+package menagerie
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// rgb is a colour with channels from 0 to 1.
+type rgb struct{ r, g, b float64 }
+
+// ansiLevels are the channel steps of xterm's 6x6x6 colour cube.
+var ansiLevels = [6]float64{0, 95, 135, 175, 215, 255}
+
+// ansiSystem are xterm's defaults for the first sixteen colours.
+var ansiSystem = [16][3]float64{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// toRGB reads an ANSI 256 index or a #RRGGBB colour.
+func toRGB(c lipgloss.Color) (rgb, bool) {
+	s := string(c)
+	if len(s) == 7 && s[0] == '#' {
+		v, err := strconv.ParseUint(s[1:], 16, 32)
+		if err != nil {
+			return rgb{}, false
+		}
+		return rgb{float64(v>>16&0xff) / 255, float64(v>>8&0xff) / 255, float64(v&0xff) / 255}, true
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 || n > 255 {
+		return rgb{}, false
+	}
+	switch {
+	case n < 16:
+		c := ansiSystem[n]
+		return rgb{c[0] / 255, c[1] / 255, c[2] / 255}, true
+	case n < 232:
+		n -= 16
+		return rgb{ansiLevels[n/36] / 255, ansiLevels[n/6%6] / 255, ansiLevels[n%6] / 255}, true
+	default:
+		v := float64(8+10*(n-232)) / 255
+		return rgb{v, v, v}, true
+	}
+}
+
+func (c rgb) luminance() float64 {
+	return 0.299*c.r + 0.587*c.g + 0.114*c.b
+}
+
+// Luminance is c's perceived brightness from 0 to 1. Colours it cannot read
+// count as black.
+func Luminance(c lipgloss.Color) float64 {
+	v, _ := toRGB(c)
+	return v.luminance()
+}
+
+func (c rgb) hex() lipgloss.Color {
+	ch := func(v float64) int { return int(math.Round(math.Max(0, math.Min(1, v)) * 255)) }
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", ch(c.r), ch(c.g), ch(c.b)))
+}
+
+// lumaSpread stretches the band the environments actually draw in, from dim
+// greys to pale cyan, over the whole palette.
+func lumaSpread(l float64) float64 {
+	return (l - 0.2) / 0.7
+}
+
+func mix(a, b rgb, t float64) rgb {
+	return rgb{a.r + (b.r-a.r)*t, a.g + (b.g-a.g)*t, a.b + (b.b-a.b)*t}
+}
+
+// applyMood pulls every drawn glyph's colour toward the mood palette. An
+// environment's colours keep their order from dark to light, so a dim
+// background glyph takes the mood's darkest shade and a highlight its
+// brightest. Higher intensity leans further into the mood and brightens.
+func (s *Scene) applyMood(grid [][]Pixel) {
+	var shades []rgb
+	for _, c := range Moods[s.Mood].Palette {
+		if v, ok := toRGB(c); ok {
+			shades = append(shades, v)
+		}
+	}
+	if len(shades) == 0 {
+		return
+	}
+	sort.Slice(shades, func(i, j int) bool { return shades[i].luminance() < shades[j].luminance() })
+
+	intensity := s.moodIntensity()
+	weight := math.Min(0.85, 0.25+0.4*intensity)
+	gain := math.Min(1.2, 0.75+0.25*intensity)
+
+	// Environments only draw with a handful of colours, so remap each once.
+	remapped := map[lipgloss.Color]lipgloss.Color{}
+	for y := range grid {
+		for x := range grid[y] {
+			p := &grid[y][x]
+			if p.Char == " " {
+				continue
+			}
+			if c, ok := remapped[p.Color]; ok {
+				p.Color = c
+				continue
+			}
+			src, ok := toRGB(p.Color)
+			if !ok {
+				continue
+			}
+			i := int(lumaSpread(src.luminance()) * float64(len(shades)))
+			i = max(0, min(i, len(shades)-1))
+			out := mix(src, shades[i], weight)
+			out = rgb{out.r * gain, out.g * gain, out.b * gain}
+			remapped[p.Color] = out.hex()
+			p.Color = remapped[p.Color]
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package midi
+
+// Player walks a Song in step with an external clock and loops at the end.
+type Player struct {
+	song   *Song
+	cursor int
+	offset float64
+}
+
+// NewPlayer starts playback of song at time zero.
+func NewPlayer(song *Song) *Player {
+	return &Player{song: song}
+}
+
+// Advance returns every event scheduled up to and including now, where now is
+// seconds on the caller's clock since playback began.
+func (p *Player) Advance(now float64) []Event {
+	if p == nil || p.song == nil || len(p.song.Events) == 0 {
+		return nil
+	}
+	var due []Event
+	for {
+		if p.cursor >= len(p.song.Events) {
+			if p.song.Duration <= 0 {
+				return due
+			}
+			p.cursor = 0
+			p.offset += p.song.Duration
+			continue
+		}
+		ev := p.song.Events[p.cursor]
+		if ev.Time+p.offset > now {
+			return due
+		}
+		due = append(due, ev)
+		p.cursor++
+	}
+}
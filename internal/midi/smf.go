@@ -0,0 +1,277 @@
+package midi
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+)
+
+// Kind identifies the channel messages the visuals care about.
+type Kind int
+
+const (
+	NoteOn Kind = iota
+	NoteOff
+	ControlChange
+)
+
+// Event is a channel message placed on an absolute timeline in seconds.
+type Event struct {
+	Time    float64
+	Kind    Kind
+	Channel int
+	Key     int // note number, or controller number for ControlChange
+	Value   int // velocity, or controller value for ControlChange
+}
+
+// Song is a Standard MIDI File flattened into a single time-ordered stream.
+type Song struct {
+	Events   []Event
+	Duration float64
+}
+
+type tickEvent struct {
+	tick  uint64
+	order int
+	event Event
+	tempo uint32 // microseconds per quarter note; non-zero for tempo changes
+	end   bool   // a track's end-of-track meta event
+}
+
+// Load reads a .mid file from disk.
+func Load(path string) (*Song, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(bufio.NewReader(f))
+}
+
+// Decode parses format 0 and 1 Standard MIDI Files. Tracks are merged and
+// tempo changes from any track are honoured.
+func Decode(r io.Reader) (*Song, error) {
+	id, body, err := readChunk(r)
+	if err != nil {
+		return nil, fmt.Errorf("midi: reading header: %w", err)
+	}
+	if id != "MThd" || len(body) < 6 {
+		return nil, errors.New("midi: not a Standard MIDI File")
+	}
+	format := binary.BigEndian.Uint16(body[0:2])
+	tracks := int(binary.BigEndian.Uint16(body[2:4]))
+	division := binary.BigEndian.Uint16(body[4:6])
+	if format > 1 {
+		return nil, fmt.Errorf("midi: unsupported format %d", format)
+	}
+	if division&0x8000 != 0 {
+		if fps := -int8(division >> 8); fps <= 0 || division&0xFF == 0 {
+			return nil, fmt.Errorf("midi: invalid SMPTE division %#04x", division)
+		}
+	}
+
+	var events []tickEvent
+	for i := 0; i < tracks; i++ {
+		id, body, err := readChunk(r)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("midi: reading track %d: %w", i, err)
+		}
+		if id != "MTrk" {
+			i--
+			continue
+		}
+		parsed, err := parseTrack(body, len(events))
+		if err != nil {
+			return nil, fmt.Errorf("midi: track %d: %w", i, err)
+		}
+		events = append(events, parsed...)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if events[i].tick != events[j].tick {
+			return events[i].tick < events[j].tick
+		}
+		return events[i].order < events[j].order
+	})
+	return timeline(events, division), nil
+}
+
+func readChunk(r io.Reader) (string, []byte, error) {
+	var head [8]byte
+	if _, err := io.ReadFull(r, head[:]); err != nil {
+		return "", nil, err
+	}
+	// The size comes from the file, so the body grows as it is read rather
+	// than being allocated up front for a chunk that may not be there.
+	size := binary.BigEndian.Uint32(head[4:8])
+	var body bytes.Buffer
+	if _, err := io.CopyN(&body, r, int64(size)); err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return "", nil, err
+	}
+	return string(head[0:4]), body.Bytes(), nil
+}
+
+func parseTrack(data []byte, order int) ([]tickEvent, error) {
+	var (
+		out     []tickEvent
+		tick    uint64
+		pos     int
+		running byte
+	)
+	for pos < len(data) {
+		delta, n, err := readVarInt(data[pos:])
+		if err != nil {
+			return nil, err
+		}
+		pos += n
+		tick += uint64(delta)
+		if pos >= len(data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+
+		status := data[pos]
+		if status < 0x80 {
+			if running == 0 {
+				return nil, errors.New("data byte without running status")
+			}
+			status = running
+		} else {
+			pos++
+		}
+
+		switch {
+		case status == 0xFF:
+			if pos >= len(data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			metaType := data[pos]
+			pos++
+			length, n, err := readVarInt(data[pos:])
+			if err != nil {
+				return nil, err
+			}
+			pos += n
+			if pos+int(length) > len(data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			meta := data[pos : pos+int(length)]
+			pos += int(length)
+			if metaType == 0x51 && len(meta) == 3 {
+				tempo := uint32(meta[0])<<16 | uint32(meta[1])<<8 | uint32(meta[2])
+				out = append(out, tickEvent{tick: tick, order: order + len(out), tempo: tempo})
+			}
+			if metaType == 0x2F {
+				out = append(out, tickEvent{tick: tick, order: order + len(out), end: true})
+				return out, nil
+			}
+		case status == 0xF0 || status == 0xF7:
+			length, n, err := readVarInt(data[pos:])
+			if err != nil {
+				return nil, err
+			}
+			pos += n + int(length)
+		default:
+			running = status
+			size := 2
+			if kind := status & 0xF0; kind == 0xC0 || kind == 0xD0 {
+				size = 1
+			}
+			if pos+size > len(data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			msg := data[pos : pos+size]
+			pos += size
+			if ev, ok := channelEvent(status, msg); ok {
+				out = append(out, tickEvent{tick: tick, order: order + len(out), event: ev})
+			}
+		}
+	}
+	return out, nil
+}
+
+func channelEvent(status byte, msg []byte) (Event, bool) {
+	ev := Event{Channel: int(status & 0x0F)}
+	switch status & 0xF0 {
+	case 0x80:
+		ev.Kind, ev.Key, ev.Value = NoteOff, int(msg[0]), int(msg[1])
+	case 0x90:
+		ev.Kind, ev.Key, ev.Value = NoteOn, int(msg[0]), int(msg[1])
+		if ev.Value == 0 {
+			ev.Kind = NoteOff
+		}
+	case 0xB0:
+		ev.Kind, ev.Key, ev.Value = ControlChange, int(msg[0]), int(msg[1])
+	default:
+		return Event{}, false
+	}
+	return ev, true
+}
+
+func readVarInt(data []byte) (uint32, int, error) {
+	var v uint32
+	for i := 0; i < 4; i++ {
+		if i >= len(data) {
+			return 0, 0, io.ErrUnexpectedEOF
+		}
+		b := data[i]
+		v = v<<7 | uint32(b&0x7F)
+		if b&0x80 == 0 {
+			return v, i + 1, nil
+		}
+	}
+	return 0, 0, errors.New("variable-length quantity too long")
+}
+
+// timeline converts tick positions to seconds, walking the tempo map as it
+// goes. SMPTE divisions use a fixed tick length and ignore tempo events. The
+// song lasts until its last end-of-track event, so a trailing rest still
+// counts before a loop comes round.
+func timeline(events []tickEvent, division uint16) *Song {
+	song := &Song{}
+	var (
+		secondsPerTick float64
+		smpte          = division&0x8000 != 0
+		lastTick       uint64
+		now            float64
+	)
+	if smpte {
+		fps := float64(-int8(division >> 8))
+		secondsPerTick = 1 / (fps * float64(division&0xFF))
+	} else {
+		if division == 0 {
+			division = 480
+		}
+		secondsPerTick = 0.5 / float64(division)
+	}
+	for _, te := range events {
+		now += float64(te.tick-lastTick) * secondsPerTick
+		lastTick = te.tick
+		if te.end {
+			song.Duration = now
+			continue
+		}
+		if te.tempo != 0 {
+			if !smpte {
+				secondsPerTick = float64(te.tempo) / 1e6 / float64(division)
+			}
+			continue
+		}
+		te.event.Time = now
+		song.Events = append(song.Events, te.event)
+	}
+	// Tracks cut off before their end-of-track event end at their last event.
+	song.Duration = math.Max(song.Duration, now)
+	return song
+}
@@ -0,0 +1,129 @@
+package midi
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// smf builds a Standard MIDI File from a division and raw track bodies.
+func smf(division uint16, tracks ...[]byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("MThd")
+	binary.Write(&b, binary.BigEndian, uint32(6))
+	binary.Write(&b, binary.BigEndian, uint16(1))
+	binary.Write(&b, binary.BigEndian, uint16(len(tracks)))
+	binary.Write(&b, binary.BigEndian, division)
+	for _, t := range tracks {
+		b.WriteString("MTrk")
+		binary.Write(&b, binary.BigEndian, uint32(len(t)))
+		b.Write(t)
+	}
+	return b.Bytes()
+}
+
+var endOfTrack = []byte{0x00, 0xFF, 0x2F, 0x00}
+
+func track(events ...byte) []byte {
+	return append(events, endOfTrack...)
+}
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     []byte
+		times    []float64
+		kinds    []Kind
+		duration float64
+	}{
+		{
+			name: "running status",
+			file: smf(480, track(
+				0x00, 0x90, 60, 100,
+				0x83, 0x60, 64, 100, // 480 ticks later, status carried over
+				0x83, 0x60, 60, 0, // velocity 0 is a note off
+			)),
+			times:    []float64{0, 0.5, 1},
+			kinds:    []Kind{NoteOn, NoteOn, NoteOff},
+			duration: 1,
+		},
+		{
+			name: "tempo change",
+			file: smf(480, track(
+				0x00, 0x90, 60, 100,
+				0x83, 0x60, 0xFF, 0x51, 0x03, 0x0F, 0x42, 0x40, // one second a beat
+				0x83, 0x60, 0x80, 60, 0,
+			)),
+			times:    []float64{0, 1.5},
+			kinds:    []Kind{NoteOn, NoteOff},
+			duration: 1.5,
+		},
+		{
+			name: "SMPTE division",
+			// 25 frames a second at 40 ticks a frame is 1000 ticks a second,
+			// whatever the tempo says.
+			file: smf(0xE728, track(
+				0x00, 0xFF, 0x51, 0x03, 0x0F, 0x42, 0x40,
+				0x83, 0x74, 0xB0, 7, 127,
+			)),
+			times:    []float64{0.5},
+			kinds:    []Kind{ControlChange},
+			duration: 0.5,
+		},
+		{
+			name: "trailing rest",
+			file: smf(480, []byte{
+				0x00, 0x90, 60, 100,
+				0x8F, 0x00, 0xFF, 0x2F, 0x00, // end of track 1920 ticks later
+			}),
+			times:    []float64{0},
+			kinds:    []Kind{NoteOn},
+			duration: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			song, err := Decode(bytes.NewReader(tt.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(song.Events) != len(tt.times) {
+				t.Fatalf("got %d events, want %d", len(song.Events), len(tt.times))
+			}
+			for i, ev := range song.Events {
+				if math.Abs(ev.Time-tt.times[i]) > 1e-9 || ev.Kind != tt.kinds[i] {
+					t.Errorf("event %d at %v kind %d, want %v kind %d", i, ev.Time, ev.Kind, tt.times[i], tt.kinds[i])
+				}
+			}
+			if math.Abs(song.Duration-tt.duration) > 1e-9 {
+				t.Errorf("duration %v, want %v", song.Duration, tt.duration)
+			}
+		})
+	}
+}
+
+func TestDecodeRejects(t *testing.T) {
+	whole := smf(480, track(0x00, 0x90, 60, 100))
+	lying := smf(480)
+	lying = append(lying, "MTrk\xFF\xFF\xFF\xF0"...)
+	lying[11] = 1 // one track, which claims nearly 4GB
+
+	tests := []struct {
+		name string
+		file []byte
+	}{
+		{"not MIDI", []byte("RIFF\x00\x00\x00\x06abcdef")},
+		{"zero SMPTE frames", smf(0x8028, track())},
+		{"zero SMPTE ticks", smf(0xE700, track())},
+		{"truncated chunk", whole[:len(whole)-3]},
+		{"truncated event", smf(480, []byte{0x00, 0x90, 60})},
+		{"data without status", smf(480, []byte{0x00, 60, 100})},
+		{"oversized chunk", lying},
+	}
+	for _, tt := range tests {
+		if _, err := Decode(bytes.NewReader(tt.file)); err == nil {
+			t.Errorf("%s: decoded without error", tt.name)
+		}
+	}
+}
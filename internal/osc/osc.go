@@ -0,0 +1,214 @@
+package osc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Message is a decoded OSC message. Args hold int32, int64, float32, float64,
+// string, []byte or bool values in the order they appeared.
+type Message struct {
+	Address string
+	Args    []any
+}
+
+// Float returns argument i as a float64, accepting any numeric type. NaN and
+// infinities are refused, since no control can do anything sensible with them.
+func (m Message) Float(i int) (float64, bool) {
+	if i < 0 || i >= len(m.Args) {
+		return 0, false
+	}
+	switch v := m.Args[i].(type) {
+	case float32:
+		f := float64(v)
+		return f, !math.IsNaN(f) && !math.IsInf(f, 0)
+	case float64:
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// Int returns argument i as an int, truncating floats.
+func (m Message) Int(i int) (int, bool) {
+	f, ok := m.Float(i)
+	return int(f), ok
+}
+
+// Parse decodes a UDP packet holding either a single message or a bundle.
+// Bundles are flattened; their time tags are ignored and contents apply
+// immediately.
+func Parse(packet []byte) ([]Message, error) {
+	if len(packet) == 0 {
+		return nil, errors.New("osc: empty packet")
+	}
+	if bytes.HasPrefix(packet, []byte("#bundle\x00")) {
+		return parseBundle(packet)
+	}
+	msg, err := parseMessage(packet)
+	if err != nil {
+		return nil, err
+	}
+	return []Message{msg}, nil
+}
+
+func parseBundle(packet []byte) ([]Message, error) {
+	pos := 16 // "#bundle\0" plus the 8-byte time tag
+	var out []Message
+	for pos < len(packet) {
+		if pos+4 > len(packet) {
+			return nil, errors.New("osc: truncated bundle element")
+		}
+		size := int(binary.BigEndian.Uint32(packet[pos:]))
+		pos += 4
+		if size < 0 || pos+size > len(packet) {
+			return nil, errors.New("osc: bundle element overruns packet")
+		}
+		msgs, err := Parse(packet[pos : pos+size])
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, msgs...)
+		pos += size
+	}
+	return out, nil
+}
+
+func parseMessage(data []byte) (Message, error) {
+	address, pos, err := readString(data, 0)
+	if err != nil {
+		return Message{}, err
+	}
+	if !strings.HasPrefix(address, "/") {
+		return Message{}, fmt.Errorf("osc: invalid address %q", address)
+	}
+	msg := Message{Address: address}
+	if pos >= len(data) {
+		return msg, nil
+	}
+	tags, pos, err := readString(data, pos)
+	if err != nil {
+		return Message{}, err
+	}
+	if !strings.HasPrefix(tags, ",") {
+		return Message{}, fmt.Errorf("osc: invalid type tags %q", tags)
+	}
+	for _, tag := range tags[1:] {
+		switch tag {
+		case 'i':
+			if pos+4 > len(data) {
+				return Message{}, errors.New("osc: truncated int32")
+			}
+			msg.Args = append(msg.Args, int32(binary.BigEndian.Uint32(data[pos:])))
+			pos += 4
+		case 'f':
+			if pos+4 > len(data) {
+				return Message{}, errors.New("osc: truncated float32")
+			}
+			msg.Args = append(msg.Args, math.Float32frombits(binary.BigEndian.Uint32(data[pos:])))
+			pos += 4
+		case 'h':
+			if pos+8 > len(data) {
+				return Message{}, errors.New("osc: truncated int64")
+			}
+			msg.Args = append(msg.Args, int64(binary.BigEndian.Uint64(data[pos:])))
+			pos += 8
+		case 'd':
+			if pos+8 > len(data) {
+				return Message{}, errors.New("osc: truncated float64")
+			}
+			msg.Args = append(msg.Args, math.Float64frombits(binary.BigEndian.Uint64(data[pos:])))
+			pos += 8
+		case 's', 'S':
+			var s string
+			s, pos, err = readString(data, pos)
+			if err != nil {
+				return Message{}, err
+			}
+			msg.Args = append(msg.Args, s)
+		case 'b':
+			if pos+4 > len(data) {
+				return Message{}, errors.New("osc: truncated blob size")
+			}
+			size := int(binary.BigEndian.Uint32(data[pos:]))
+			pos += 4
+			if size < 0 || pos+pad(size) > len(data) {
+				return Message{}, errors.New("osc: truncated blob")
+			}
+			msg.Args = append(msg.Args, append([]byte(nil), data[pos:pos+size]...))
+			pos += pad(size)
+		case 'T':
+			msg.Args = append(msg.Args, true)
+		case 'F':
+			msg.Args = append(msg.Args, false)
+		case 'N', 'I':
+		default:
+			return Message{}, fmt.Errorf("osc: unsupported type tag %q", tag)
+		}
+	}
+	return msg, nil
+}
+
+func readString(data []byte, pos int) (string, int, error) {
+	if pos > len(data) {
+		return "", 0, errors.New("osc: truncated string")
+	}
+	end := bytes.IndexByte(data[pos:], 0)
+	if end < 0 {
+		return "", 0, errors.New("osc: unterminated string")
+	}
+	s := string(data[pos : pos+end])
+	return s, pos + pad(end+1), nil
+}
+
+func pad(n int) int {
+	return (n + 3) &^ 3
+}
+
+// Encode serialises msg. Supported argument types are int, int32, int64,
+// float32, float64 and string.
+func Encode(msg Message) ([]byte, error) {
+	var buf bytes.Buffer
+	writeString(&buf, msg.Address)
+	tags := []byte{','}
+	var args bytes.Buffer
+	for _, arg := range msg.Args {
+		switch v := arg.(type) {
+		case int:
+			tags = append(tags, 'i')
+			binary.Write(&args, binary.BigEndian, int32(v))
+		case int32:
+			tags = append(tags, 'i')
+			binary.Write(&args, binary.BigEndian, v)
+		case int64:
+			tags = append(tags, 'h')
+			binary.Write(&args, binary.BigEndian, v)
+		case float32:
+			tags = append(tags, 'f')
+			binary.Write(&args, binary.BigEndian, math.Float32bits(v))
+		case float64:
+			tags = append(tags, 'd')
+			binary.Write(&args, binary.BigEndian, math.Float64bits(v))
+		case string:
+			tags = append(tags, 's')
+			writeString(&args, v)
+		default:
+			return nil, fmt.Errorf("osc: cannot encode %T", arg)
+		}
+	}
+	writeString(&buf, string(tags))
+	buf.Write(args.Bytes())
+	return buf.Bytes(), nil
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	buf.WriteString(s)
+	buf.Write(make([]byte, pad(len(s)+1)-len(s)))
+}
@@ -0,0 +1,81 @@
+package osc
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	want := Message{
+		Address: "/garden/scene",
+		Args:    []any{int32(3), int64(-9), float32(0.5), 2.25, "bloom"},
+	}
+	packet, err := Encode(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Parse(packet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || !reflect.DeepEqual(got[0], want) {
+		t.Fatalf("Parse(Encode(msg)) = %+v, want %+v", got, want)
+	}
+}
+
+func TestTruncated(t *testing.T) {
+	packet, err := Encode(Message{Address: "/nyan/mood", Args: []any{"cosmic", int32(2)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Cutting inside the address can leave a bare address, which is a valid
+	// message, but every cut after the type tags loses an argument.
+	const args = 12 + 4 // padded "/nyan/mood" and ",si"
+	for n := 1; n < len(packet); n++ {
+		if _, err := Parse(packet[:n]); err == nil && n > args {
+			t.Errorf("Parse of %d of %d bytes succeeded", n, len(packet))
+		}
+	}
+
+	// A blob whose padding runs off the end, followed by a string tag.
+	blob := []byte("/b\x00\x00,bs\x00\x00\x00\x00\x01x")
+	if _, err := Parse(blob); err == nil {
+		t.Error("Parse accepted a blob padded past the end of the packet")
+	}
+
+	// A string whose padding runs off the end, followed by another string.
+	str := []byte("/s\x00\x00,ss\x00abcde\x00")
+	if _, err := Parse(str); err == nil {
+		t.Error("Parse accepted a string padded past the end of the packet")
+	}
+}
+
+func TestFloat(t *testing.T) {
+	m := Message{Args: []any{
+		float32(0.5), 2.25, int32(-3), int64(7), "x",
+		math.NaN(), math.Inf(1), float32(math.Inf(-1)),
+	}}
+	tests := []struct {
+		i    int
+		want float64
+		ok   bool
+	}{
+		{0, 0.5, true},
+		{1, 2.25, true},
+		{2, -3, true},
+		{3, 7, true},
+		{4, 0, false},
+		{5, 0, false},
+		{6, 0, false},
+		{7, 0, false},
+		{8, 0, false},
+		{-1, 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := m.Float(tt.i)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("Float(%d) = %v, %v, want %v, %v", tt.i, got, ok, tt.want, tt.ok)
+		}
+	}
+}
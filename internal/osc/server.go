@@ -0,0 +1,61 @@
+package osc
+
+import (
+	"errors"
+	"net"
+	"strings"
+)
+
+const maxPacket = 65507
+
+// Server receives OSC packets over UDP.
+type Server struct {
+	conn *net.UDPConn
+}
+
+// Listen binds addr. A bare port such as "9000" or ":9000" binds the loopback
+// interface only, keeping remote control local to the machine.
+func Listen(addr string) (*Server, error) {
+	if !strings.Contains(addr, ":") {
+		addr = ":" + addr
+	}
+	if strings.HasPrefix(addr, ":") {
+		addr = "127.0.0.1" + addr
+	}
+	udpAddr, err := net.ResolveUDPAddr("udp", addr)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", udpAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &Server{conn: conn}, nil
+}
+
+// Serve blocks, calling handle for every message received, until Close is
+// called. Malformed packets are dropped.
+func (s *Server) Serve(handle func(Message)) error {
+	buf := make([]byte, maxPacket)
+	for {
+		n, _, err := s.conn.ReadFromUDP(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		msgs, err := Parse(buf[:n])
+		if err != nil {
+			continue
+		}
+		for _, msg := range msgs {
+			handle(msg)
+		}
+	}
+}
+
+// Close stops the server.
+func (s *Server) Close() error {
+	return s.conn.Close()
+}
@@ -0,0 +1,68 @@
+package sprite
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Encode writes s in the sprite file format. The anchor is only written when
+// it differs from the centre Parse would pick, and palette entries come out
+// in glyph order so saved files diff cleanly.
+func Encode(w io.Writer, s *Sprite) error {
+	b := bufio.NewWriter(w)
+	if s.Name != "" {
+		fmt.Fprintf(b, "name %s\n", s.Name)
+	}
+	if s.Mode == PingPong {
+		b.WriteString("mode pingpong\n")
+	}
+	width, height := s.bounds()
+	if s.AnchorX != width/2 || s.AnchorY != height/2 {
+		fmt.Fprintf(b, "anchor %d %d\n", s.AnchorX, s.AnchorY)
+	}
+
+	glyphs := make([]rune, 0, len(s.Palette))
+	for g := range s.Palette {
+		glyphs = append(glyphs, g)
+	}
+	sort.Slice(glyphs, func(i, j int) bool { return glyphs[i] < glyphs[j] })
+	b.WriteString("\npalette\n")
+	for _, g := range glyphs {
+		fmt.Fprintf(b, "%c %s\n", g, s.Palette[g])
+	}
+
+	for i, f := range s.Frames {
+		name := strings.Join(strings.Fields(f.Name), "-")
+		if name == "" {
+			name = fmt.Sprint(i)
+		}
+		fmt.Fprintf(b, "\nframe %s", name)
+		if f.Duration > 0 && f.Duration != DefaultDuration {
+			fmt.Fprintf(b, " %s", f.Duration)
+		}
+		b.WriteByte('\n')
+		for _, row := range f.Rows {
+			// Blank rows would end the frame early when read back.
+			if strings.TrimSpace(row) == "" {
+				row = strings.Repeat(string(Transparent), max(len([]rune(row)), 1))
+			}
+			b.WriteString(row)
+			b.WriteByte('\n')
+		}
+	}
+	return b.Flush()
+}
+
+func (s *Sprite) bounds() (int, int) {
+	width, height := 0, 0
+	for _, f := range s.Frames {
+		height = max(height, len(f.Rows))
+		for _, row := range f.Rows {
+			width = max(width, len([]rune(row)))
+		}
+	}
+	return width, height
+}
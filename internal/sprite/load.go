@@ -0,0 +1,95 @@
+package sprite
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Ext is the file extension sprite files are loaded from.
+const Ext = ".sprite"
+
+//go:embed assets/*.sprite
+var assets embed.FS
+
+// Library maps sprite names, the file names without the extension, to
+// sprites.
+type Library map[string]*Sprite
+
+// DefaultDir is where user sprites live unless a program is told otherwise.
+// It is empty when the platform has no config directory.
+func DefaultDir() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "charm-experiments", "sprites")
+}
+
+// Load reads the built-in sprites, then any in userDir. A user sprite with
+// the same name as a built-in one replaces it. A missing userDir is not an
+// error.
+func Load(userDir string) (Library, error) {
+	lib := Library{}
+	if err := lib.addFS(assets, "assets", "built-in sprites"); err != nil {
+		return nil, err
+	}
+	if userDir == "" {
+		return lib, nil
+	}
+	if _, err := os.Stat(userDir); os.IsNotExist(err) {
+		return lib, nil
+	}
+	if err := lib.addFS(os.DirFS(userDir), ".", userDir); err != nil {
+		return nil, err
+	}
+	return lib, nil
+}
+
+func (lib Library) addFS(fsys fs.FS, dir, label string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || path.Ext(e.Name()) != Ext {
+			continue
+		}
+		f, err := fsys.Open(path.Join(dir, e.Name()))
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(e.Name(), Ext)
+		s, err := Parse(name, f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", label, err)
+		}
+		lib[name] = s
+	}
+	return nil
+}
+
+// Get returns the named sprite, or an error naming what is missing.
+func (lib Library) Get(name string) (*Sprite, error) {
+	s, ok := lib[name]
+	if !ok {
+		return nil, fmt.Errorf("no sprite named %q", name)
+	}
+	return s, nil
+}
+
+// Names lists the library's sprites in order.
+func (lib Library) Names() []string {
+	names := make([]string, 0, len(lib))
+	for name := range lib {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,49 @@
+package sprite
+
+// Swatch is one entry of the standard palette.
+type Swatch struct {
+	Glyph rune
+	Color string
+	Name  string
+}
+
+// Standard is the palette nyan-cat's creatures were first drawn in. Editors
+// offer it so new art shares the same letters and colours.
+var Standard = []Swatch{
+	{'K', "0", "black outline"},
+	{'W', "15", "white"},
+	{'J', "93", "jellyfish purple"},
+	{'T', "51", "translucent cyan"},
+	{'C', "46", "cactus green"},
+	{'S', "226", "spines yellow"},
+	{'M', "244", "metal gray"},
+	{'G', "208", "gear bronze"},
+	{'B', "130", "brown fur"},
+	{'F', "196", "fire red"},
+	{'E', "21", "electric blue"},
+	{'V', "129", "void purple"},
+	{'N', "201", "neon pink"},
+	{'Y', "226", "glowing yellow"},
+	{'R', "196", "crimson red"},
+	{'P', "93", "prismatic purple"},
+	{'A', "39", "aqua blue"},
+	{'O', "208", "orange"},
+	{'I', "87", "iridescent silver"},
+	{'U', "99", "ultraviolet"},
+	{'X', "160", "exotic red"},
+	{'Z', "240", "zinc gray"},
+	{'Q', "45", "quantum cyan"},
+	{'H', "220", "holographic gold"},
+	{'L', "82", "luminous green"},
+	{'D', "88", "deep crimson"},
+}
+
+// StandardColor returns the standard colour for glyph.
+func StandardColor(glyph rune) (string, bool) {
+	for _, sw := range Standard {
+		if sw.Glyph == glyph {
+			return sw.Color, true
+		}
+	}
+	return "", false
+}
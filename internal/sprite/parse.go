@@ -0,0 +1,141 @@
+package sprite
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Parse reads a sprite file. name is used in errors and as the sprite's name
+// when the file does not give one.
+func Parse(name string, r io.Reader) (*Sprite, error) {
+	s := &Sprite{Name: name, Palette: map[rune]string{}, AnchorX: -1, AnchorY: -1}
+	const (
+		inHeader = iota
+		inPalette
+		inFrame
+	)
+	state := inHeader
+	sc := bufio.NewScanner(r)
+	line := 0
+	fail := func(format string, args ...any) error {
+		return fmt.Errorf("%s:%d: %s", name, line, fmt.Sprintf(format, args...))
+	}
+	for sc.Scan() {
+		line++
+		text := strings.TrimRight(sc.Text(), "\r")
+		if state == inFrame {
+			if strings.TrimSpace(text) == "" {
+				state = inHeader
+				continue
+			}
+			if !strings.HasPrefix(text, "frame ") {
+				f := &s.Frames[len(s.Frames)-1]
+				f.Rows = append(f.Rows, text)
+				continue
+			}
+		}
+		text = stripComment(text)
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+		if state == inPalette && utf8.RuneCountInString(fields[0]) == 1 {
+			if len(fields) != 2 {
+				return nil, fail("palette entries are a glyph and a colour")
+			}
+			glyph, _ := utf8.DecodeRuneInString(fields[0])
+			if IsTransparent(glyph) {
+				return nil, fail("%q is transparent and cannot have a colour", glyph)
+			}
+			s.Palette[glyph] = fields[1]
+			continue
+		}
+		state = inHeader
+		switch fields[0] {
+		case "name":
+			s.Name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), "name"))
+		case "mode":
+			if len(fields) != 2 {
+				return nil, fail("mode takes loop or pingpong")
+			}
+			switch fields[1] {
+			case "loop":
+				s.Mode = Loop
+			case "pingpong":
+				s.Mode = PingPong
+			default:
+				return nil, fail("unknown mode %q (want loop or pingpong)", fields[1])
+			}
+		case "anchor":
+			if len(fields) != 3 {
+				return nil, fail("anchor takes an x and a y")
+			}
+			x, errX := strconv.Atoi(fields[1])
+			y, errY := strconv.Atoi(fields[2])
+			if errX != nil || errY != nil || x < 0 || y < 0 {
+				return nil, fail("anchor must be two non-negative integers")
+			}
+			s.AnchorX, s.AnchorY = x, y
+		case "palette":
+			state = inPalette
+		case "frame":
+			f := Frame{Duration: DefaultDuration}
+			if len(fields) > 1 {
+				f.Name = fields[1]
+			}
+			if len(fields) > 2 {
+				d, err := time.ParseDuration(fields[2])
+				if err != nil || d <= 0 {
+					return nil, fail("bad frame duration %q", fields[2])
+				}
+				f.Duration = d
+			}
+			if len(fields) > 3 {
+				return nil, fail("frame takes a name and a duration")
+			}
+			if f.Name == "" {
+				f.Name = strconv.Itoa(len(s.Frames))
+			}
+			s.Frames = append(s.Frames, f)
+			state = inFrame
+		default:
+			return nil, fail("unknown directive %q", fields[0])
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(s.Frames) == 0 {
+		return nil, fmt.Errorf("%s: no frames", name)
+	}
+	for _, f := range s.Frames {
+		if len(f.Rows) == 0 {
+			return nil, fmt.Errorf("%s: frame %s is empty", name, f.Name)
+		}
+	}
+	s.Width, s.Height = s.bounds()
+	if s.AnchorX < 0 {
+		s.AnchorX, s.AnchorY = s.Width/2, s.Height/2
+	}
+	return s, nil
+}
+
+// stripComment drops a trailing comment. A comment is a '#' at the start of
+// the line or after a space, followed by a space or the line end, so hex
+// colours like #FF87D7 survive.
+func stripComment(text string) string {
+	for i := 0; i < len(text); i++ {
+		if text[i] != '#' || (i > 0 && text[i-1] != ' ' && text[i-1] != '\t') {
+			continue
+		}
+		if i+1 == len(text) || text[i+1] == ' ' || text[i+1] == '\t' {
+			return text[:i]
+		}
+	}
+	return text
+}
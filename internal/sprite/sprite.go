@@ -0,0 +1,121 @@
+// Package sprite reads the plain-text pixel art shared by the terminal toys.
+//
+// A sprite file declares a palette mapping glyphs to colours, then one or more
+// named frames drawn with those glyphs:
+//
+//	# Comments start with a hash.
+//	name   Jellyfish Horse
+//	mode   pingpong
+//	anchor 9 6
+//
+//	palette
+//	K 0        # ANSI index
+//	J #AF5FFF  # or hex
+//
+//	frame idle 320ms
+//	...KKK...
+//	..KJJJK..
+//
+//	frame sway 400ms
+//	...
+//
+// Frames run until the next blank line or frame header. '.' and ' ' are
+// transparent, and '#' followed by a space starts a comment outside frames,
+// so it cannot be given a colour. Glyphs without a palette entry are left for
+// the program to draw as text. The anchor is the pixel placed at the
+// sprite's on-screen position and defaults to the centre of the largest
+// frame.
+package sprite
+
+import (
+	"math"
+	"time"
+)
+
+// Mode decides what happens when an animation reaches its last frame.
+type Mode int
+
+const (
+	Loop     Mode = iota // wrap back to the first frame
+	PingPong             // run backwards to the first frame, then forwards again
+)
+
+// DefaultDuration is how long a frame shows when its header gives no time.
+const DefaultDuration = 100 * time.Millisecond
+
+// Transparent is the glyph written for empty pixels.
+const Transparent = '.'
+
+// Frame is one pose, a row of glyphs per line.
+type Frame struct {
+	Name     string
+	Rows     []string
+	Duration time.Duration
+}
+
+// Sprite is a parsed sprite file.
+type Sprite struct {
+	Name    string
+	Palette map[rune]string
+	Frames  []Frame
+	Mode    Mode
+
+	AnchorX, AnchorY int
+	Width, Height    int
+}
+
+// IsTransparent reports whether glyph leaves the pixel behind it showing.
+func IsTransparent(glyph rune) bool {
+	return glyph == Transparent || glyph == ' '
+}
+
+// Color returns the colour for glyph, if the palette has one.
+func (s *Sprite) Color(glyph rune) (string, bool) {
+	c, ok := s.Palette[glyph]
+	return c, ok
+}
+
+// order lists the frame indices for one full cycle. Ping-pong skips repeating
+// the end frames so they hold for their duration once, not twice.
+func (s *Sprite) order() []int {
+	n := len(s.Frames)
+	seq := make([]int, 0, 2*n)
+	for i := 0; i < n; i++ {
+		seq = append(seq, i)
+	}
+	if s.Mode == PingPong {
+		for i := n - 2; i > 0; i-- {
+			seq = append(seq, i)
+		}
+	}
+	return seq
+}
+
+// Cycle is the time one full pass of the animation takes.
+func (s *Sprite) Cycle() time.Duration {
+	var total time.Duration
+	for _, i := range s.order() {
+		total += s.Frames[i].Duration
+	}
+	return total
+}
+
+// FrameAt returns the frame showing elapsed into the animation.
+func (s *Sprite) FrameAt(elapsed time.Duration) *Frame {
+	seq := s.order()
+	total := s.Cycle()
+	if total <= 0 {
+		return &s.Frames[0]
+	}
+	t := time.Duration(math.Mod(float64(elapsed), float64(total)))
+	if t < 0 {
+		t += total
+	}
+	for _, i := range seq {
+		if t < s.Frames[i].Duration {
+			return &s.Frames[i]
+		}
+		t -= s.Frames[i].Duration
+	}
+	return &s.Frames[seq[len(seq)-1]]
+}
@@ -0,0 +1,65 @@
+package sprite
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeParseRoundTrip(t *testing.T) {
+	lib, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sprites := []*Sprite{{
+		Name:    "Odd Anchor",
+		Palette: map[rune]string{'A': "#FF0000", 'b': "33", 'Z': "#00FF00"},
+		Frames: []Frame{
+			{Name: "first pose", Rows: []string{"AbZ", ".A.", "   "}, Duration: 250 * time.Millisecond},
+			{Name: "", Rows: []string{"Z", "xyz"}, Duration: DefaultDuration},
+		},
+		Mode:    PingPong,
+		AnchorX: 0,
+		AnchorY: 2,
+	}}
+	for _, name := range lib.Names() {
+		s, _ := lib.Get(name)
+		sprites = append(sprites, s)
+	}
+
+	for _, want := range sprites {
+		var buf bytes.Buffer
+		if err := Encode(&buf, want); err != nil {
+			t.Fatal(err)
+		}
+		got, err := Parse("", &buf)
+		if err != nil {
+			t.Fatalf("%s: %v\n%s", want.Name, err, buf.String())
+		}
+		if got.Name != want.Name || got.Mode != want.Mode || !reflect.DeepEqual(got.Palette, want.Palette) {
+			t.Errorf("%s: header came back as %q mode %d palette %v", want.Name, got.Name, got.Mode, got.Palette)
+		}
+		if got.AnchorX != want.AnchorX || got.AnchorY != want.AnchorY {
+			t.Errorf("%s: anchor %d,%d, want %d,%d", want.Name, got.AnchorX, got.AnchorY, want.AnchorX, want.AnchorY)
+		}
+		if len(got.Frames) != len(want.Frames) {
+			t.Fatalf("%s: %d frames, want %d", want.Name, len(got.Frames), len(want.Frames))
+		}
+		for i, f := range got.Frames {
+			w := want.Frames[i]
+			rows := make([]string, len(w.Rows))
+			for j, row := range w.Rows {
+				// Encode writes blank rows as transparent pixels.
+				if strings.TrimSpace(row) == "" {
+					row = strings.Repeat(string(Transparent), len(row))
+				}
+				rows[j] = row
+			}
+			if f.Duration != w.Duration || !reflect.DeepEqual(f.Rows, rows) {
+				t.Errorf("%s frame %d: %s %q, want %s %q", want.Name, i, f.Duration, f.Rows, w.Duration, rows)
+			}
+		}
+	}
+}
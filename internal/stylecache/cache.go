@@ -0,0 +1,251 @@
+// Package stylecache keeps a bounded table of lipgloss styles keyed by
+// quantised colour pairs, so per-cell rendering neither formats key strings
+// nor grows without limit as gradients mint new hex colours.
+package stylecache
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultCapacity comfortably holds a frame of gradient-heavy canvas once
+// colours are quantised.
+const DefaultCapacity = 4096
+
+// Bits of precision kept per RGB channel. Five bits merges gradient steps
+// closer than 1/32 of the channel range, which is below what a terminal cell
+// can show next to its neighbours.
+const Bits = 5
+
+const (
+	colorSet  = 1 << 16 // the colour was given at all
+	colorANSI = 1 << 15 // low bits are an ANSI index, not packed RGB
+	colorBits = 17
+	boldBit   = 1 << (2 * colorBits)
+)
+
+// Key packs a quantised foreground, background and bold flag into an integer.
+type Key uint64
+
+// Stats reports how well the cache is doing.
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Size      int
+	Capacity  int
+}
+
+// HitRate is the fraction of lookups served from the table.
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("styles %d/%d • hit %.1f%% • evicted %d", s.Size, s.Capacity, s.HitRate()*100, s.Evictions)
+}
+
+type entry struct {
+	key        Key
+	style      lipgloss.Style
+	prev, next int
+}
+
+// Cache is a least-recently-used style table. Entries live in a slice linked
+// by index so lookups and evictions do not allocate once it is full.
+type Cache struct {
+	capacity int
+	index    map[Key]int
+	entries  []entry
+	head     int // most recently used, -1 when empty
+	tail     int // least recently used
+	stats    Stats
+}
+
+// New returns a cache holding at most capacity styles.
+func New(capacity int) *Cache {
+	if capacity < 1 {
+		capacity = DefaultCapacity
+	}
+	return &Cache{
+		capacity: capacity,
+		index:    make(map[Key]int, capacity),
+		entries:  make([]entry, 0, capacity),
+		head:     -1,
+		tail:     -1,
+	}
+}
+
+// KeyOf quantises the colours and packs them with the bold flag. Colours are
+// either "#RRGGBB" hex or ANSI indexes such as "205"; anything else is treated
+// as unset.
+func KeyOf(fg, bg string, bold bool) Key {
+	k := Key(packColor(fg)) | Key(packColor(bg))<<colorBits
+	if bold {
+		k |= boldBit
+	}
+	return k
+}
+
+// Style returns the style for the given colours, building and caching it on a
+// miss.
+func (c *Cache) Style(fg, bg string, bold bool) lipgloss.Style {
+	key := KeyOf(fg, bg, bold)
+	if i, ok := c.index[key]; ok {
+		c.stats.Hits++
+		c.touch(i)
+		return c.entries[i].style
+	}
+	c.stats.Misses++
+	style := build(key)
+	c.insert(key, style)
+	return style
+}
+
+// Render styles text, skipping the lookup entirely for empty strings.
+func (c *Cache) Render(text, fg, bg string, bold bool) string {
+	if text == "" {
+		return ""
+	}
+	return c.Style(fg, bg, bold).Render(text)
+}
+
+// Stats returns a snapshot of the hit counters.
+func (c *Cache) Stats() Stats {
+	s := c.stats
+	s.Size = len(c.entries)
+	s.Capacity = c.capacity
+	return s
+}
+
+func (c *Cache) insert(key Key, style lipgloss.Style) {
+	if len(c.entries) < c.capacity {
+		c.entries = append(c.entries, entry{key: key, style: style, prev: -1, next: -1})
+		i := len(c.entries) - 1
+		c.index[key] = i
+		c.pushFront(i)
+		return
+	}
+	// Reuse the least recently used slot.
+	i := c.tail
+	c.unlink(i)
+	delete(c.index, c.entries[i].key)
+	c.entries[i] = entry{key: key, style: style, prev: -1, next: -1}
+	c.index[key] = i
+	c.pushFront(i)
+	c.stats.Evictions++
+}
+
+func (c *Cache) touch(i int) {
+	if c.head == i {
+		return
+	}
+	c.unlink(i)
+	c.pushFront(i)
+}
+
+func (c *Cache) unlink(i int) {
+	e := &c.entries[i]
+	if e.prev >= 0 {
+		c.entries[e.prev].next = e.next
+	} else {
+		c.head = e.next
+	}
+	if e.next >= 0 {
+		c.entries[e.next].prev = e.prev
+	} else {
+		c.tail = e.prev
+	}
+	e.prev, e.next = -1, -1
+}
+
+func (c *Cache) pushFront(i int) {
+	e := &c.entries[i]
+	e.prev = -1
+	e.next = c.head
+	if c.head >= 0 {
+		c.entries[c.head].prev = i
+	}
+	c.head = i
+	if c.tail < 0 {
+		c.tail = i
+	}
+}
+
+func build(key Key) lipgloss.Style {
+	style := lipgloss.NewStyle()
+	if fg, ok := unpackColor(uint32(key) & (1<<colorBits - 1)); ok {
+		style = style.Foreground(fg)
+	}
+	if bg, ok := unpackColor(uint32(key>>colorBits) & (1<<colorBits - 1)); ok {
+		style = style.Background(bg)
+	}
+	if key&boldBit != 0 {
+		style = style.Bold(true)
+	}
+	return style
+}
+
+func packColor(s string) uint32 {
+	if len(s) == 7 && s[0] == '#' {
+		var rgb [3]uint32
+		for ch := 0; ch < 3; ch++ {
+			hi, ok1 := hexDigit(s[1+ch*2])
+			lo, ok2 := hexDigit(s[2+ch*2])
+			if !ok1 || !ok2 {
+				return 0
+			}
+			rgb[ch] = (hi<<4 | lo) >> (8 - Bits)
+		}
+		return colorSet | rgb[0]<<(2*Bits) | rgb[1]<<Bits | rgb[2]
+	}
+	if n := len(s); n > 0 && n <= 3 {
+		var idx uint32
+		for i := 0; i < n; i++ {
+			if s[i] < '0' || s[i] > '9' {
+				return 0
+			}
+			idx = idx*10 + uint32(s[i]-'0')
+		}
+		if idx <= 255 {
+			return colorSet | colorANSI | idx
+		}
+	}
+	return 0
+}
+
+func unpackColor(v uint32) (lipgloss.Color, bool) {
+	if v&colorSet == 0 {
+		return "", false
+	}
+	if v&colorANSI != 0 {
+		return lipgloss.Color(fmt.Sprint(v & 0xff)), true
+	}
+	const mask = 1<<Bits - 1
+	expand := func(q uint32) uint32 {
+		// Spread the kept bits back over the full byte so pure white
+		// stays white.
+		return q<<(8-Bits) | q>>(2*Bits-8)
+	}
+	r := expand(v >> (2 * Bits) & mask)
+	g := expand(v >> Bits & mask)
+	b := expand(v & mask)
+	return lipgloss.Color(fmt.Sprintf("#%02X%02X%02X", r, g, b)), true
+}
+
+func hexDigit(c byte) (uint32, bool) {
+	switch {
+	case c >= '0' && c <= '9':
+		return uint32(c - '0'), true
+	case c >= 'a' && c <= 'f':
+		return uint32(c-'a') + 10, true
+	case c >= 'A' && c <= 'F':
+		return uint32(c-'A') + 10, true
+	}
+	return 0, false
+}
@@ -0,0 +1,85 @@
+package stylecache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestKeyQuantisation(t *testing.T) {
+	tests := []struct {
+		a, b string
+		same bool
+	}{
+		{"#FF0000", "#F80707", true}, // within one 5-bit step
+		{"#FF0000", "#F70000", false},
+		{"#ff8000", "#FF8000", true},
+		{"205", "205", true},
+		{"205", "206", false},
+		{"0", "#000000", false}, // ANSI and RGB never collide
+		{"", "not a colour", true},
+		{"256", "", true},
+	}
+	for _, tt := range tests {
+		if got := KeyOf(tt.a, "", false) == KeyOf(tt.b, "", false); got != tt.same {
+			t.Errorf("KeyOf(%q) == KeyOf(%q) is %v, want %v", tt.a, tt.b, got, tt.same)
+		}
+	}
+	if KeyOf("#123456", "", false) == KeyOf("", "#123456", false) {
+		t.Error("foreground and background share key bits")
+	}
+	if KeyOf("1", "2", true) == KeyOf("1", "2", false) {
+		t.Error("bold is not part of the key")
+	}
+}
+
+func TestWhiteSurvivesQuantisation(t *testing.T) {
+	if c, _ := unpackColor(packColor("#FFFFFF")); c != "#FFFFFF" {
+		t.Errorf("white came back as %s", c)
+	}
+}
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2)
+	c.Style("1", "", false)
+	c.Style("2", "", false)
+	c.Style("1", "", false) // 2 is now the oldest
+	c.Style("3", "", false)
+
+	if s := c.Stats(); s.Evictions != 1 || s.Size != 2 {
+		t.Fatalf("after overflow got %+v, want 1 eviction and 2 entries", s)
+	}
+	misses := c.Stats().Misses
+	c.Style("1", "", false)
+	c.Style("3", "", false)
+	if got := c.Stats().Misses; got != misses {
+		t.Errorf("recently used styles were evicted: %d new misses", got-misses)
+	}
+	c.Style("2", "", false)
+	if got := c.Stats().Misses; got != misses+1 {
+		t.Error("least recently used style was kept")
+	}
+}
+
+// BenchmarkRender200x60 renders one frame of a 200x60 canvas per op with a
+// gradient that shifts each frame, the way harmonic-garden's canvas does.
+func BenchmarkRender200x60(b *testing.B) {
+	const width, height, frames = 200, 60, 16
+	colors := make([][]string, frames)
+	for f := range colors {
+		colors[f] = make([]string, width*height)
+		for i := range colors[f] {
+			x, y := i%width, i/width
+			colors[f][i] = fmt.Sprintf("#%02X%02X%02X", (x+f)*255/(width+frames), y*255/height, (x+y)%256)
+		}
+	}
+	c := New(DefaultCapacity)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		frame := colors[n%frames]
+		for i, fg := range frame {
+			c.Render("*", fg, "#0B0618", i%7 == 0)
+		}
+	}
+}
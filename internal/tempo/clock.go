@@ -0,0 +1,150 @@
+package tempo
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	MinBPM        = 40.0
+	MaxBPM        = 240.0
+	DefaultBPM    = 120.0
+	BeatsPerBar   = 4
+	maxTaps       = 5
+	tapResetAfter = 2.0
+)
+
+// Clock is a beat counter advanced by the animation delta rather than wall
+// time, so quantised motion stays consistent when frames are dropped.
+type Clock struct {
+	BPM    float64
+	Locked bool
+
+	beats    float64
+	prev     float64
+	lastTap  float64
+	tapGaps  []float64
+	tapCount int
+}
+
+// New returns a clock at bpm, clamped to the supported range.
+func New(bpm float64) Clock {
+	if bpm <= 0 {
+		bpm = DefaultBPM
+	}
+	return Clock{BPM: clamp(bpm, MinBPM, MaxBPM)}
+}
+
+// Advance moves the clock forward by dt seconds.
+func (c *Clock) Advance(dt float64) {
+	c.prev = c.beats
+	c.beats += dt * c.BPM / 60
+}
+
+// Beats is the continuous number of beats elapsed.
+func (c Clock) Beats() float64 {
+	return c.beats
+}
+
+// Phase is the position within the current beat in [0, 1).
+func (c Clock) Phase() float64 {
+	return c.beats - math.Floor(c.beats)
+}
+
+// BarPhase is the position within the current bar in [0, 1).
+func (c Clock) BarPhase() float64 {
+	bar := c.beats / BeatsPerBar
+	return bar - math.Floor(bar)
+}
+
+// BeatInBar is the zero-based index of the current beat within its bar.
+func (c Clock) BeatInBar() int {
+	return int(math.Floor(c.beats)) % BeatsPerBar
+}
+
+// BeatSeconds is the length of a single beat.
+func (c Clock) BeatSeconds() float64 {
+	return 60 / c.BPM
+}
+
+// Crossed reports whether the last Advance stepped over a boundary of the
+// given size in beats (1 = every beat, 0.5 = eighths, 4 = every bar).
+func (c Clock) Crossed(step float64) bool {
+	if step <= 0 {
+		return false
+	}
+	return math.Floor(c.beats/step) != math.Floor(c.prev/step)
+}
+
+// Pulse is a sharp attack that decays over the beat, handy for driving sizes.
+func (c Clock) Pulse() float64 {
+	return math.Exp(-c.Phase() * 4)
+}
+
+// Quantize snaps a duration in seconds to the nearest power-of-two division
+// or multiple of a beat and returns it in beats.
+func (c Clock) Quantize(seconds float64) float64 {
+	if seconds <= 0 {
+		return 1
+	}
+	beats := seconds / c.BeatSeconds()
+	exp := math.Round(math.Log2(beats))
+	return math.Pow(2, clamp(exp, -3, 3))
+}
+
+// Nudge shifts the tempo by delta beats per minute.
+func (c *Clock) Nudge(delta float64) {
+	c.BPM = clamp(c.BPM+delta, MinBPM, MaxBPM)
+}
+
+// Tap registers a tap at time now, in wall-clock seconds so the gaps measure
+// the player's own timing. After two taps the tempo follows the average gap,
+// and the beat is realigned so the tap lands on the nearest beat.
+func (c *Clock) Tap(now float64) {
+	if c.tapCount > 0 && now-c.lastTap > tapResetAfter {
+		c.tapGaps = c.tapGaps[:0]
+		c.tapCount = 0
+	}
+	if c.tapCount > 0 {
+		c.tapGaps = append(c.tapGaps, now-c.lastTap)
+		if len(c.tapGaps) > maxTaps-1 {
+			c.tapGaps = c.tapGaps[len(c.tapGaps)-(maxTaps-1):]
+		}
+		var sum float64
+		for _, gap := range c.tapGaps {
+			sum += gap
+		}
+		if avg := sum / float64(len(c.tapGaps)); avg > 0 {
+			c.BPM = clamp(60/avg, MinBPM, MaxBPM)
+		}
+	}
+	c.lastTap = now
+	c.tapCount++
+	c.beats = math.Round(c.beats)
+	c.prev = c.beats
+}
+
+// Indicator renders the bar as dots with the current beat lit.
+func (c Clock) Indicator() string {
+	var b strings.Builder
+	current := c.BeatInBar()
+	for i := 0; i < BeatsPerBar; i++ {
+		if i == current {
+			b.WriteRune('●')
+		} else {
+			b.WriteRune('○')
+		}
+	}
+	return fmt.Sprintf("%3.0f %s", c.BPM, b.String())
+}
+
+func clamp(v, lo, hi float64) float64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}